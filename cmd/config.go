@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/config/check"
+	"github.com/supabase/cli/internal/config/decrypt"
+	"github.com/supabase/cli/internal/config/encrypt"
+	"github.com/supabase/cli/internal/config/env"
+	"github.com/supabase/cli/internal/config/get"
+	"github.com/supabase/cli/internal/config/importer"
+	"github.com/supabase/cli/internal/config/jsonschema"
+	"github.com/supabase/cli/internal/config/migrate"
+	"github.com/supabase/cli/internal/config/reload"
+	"github.com/supabase/cli/internal/config/reset"
+	"github.com/supabase/cli/internal/config/secrets"
+	"github.com/supabase/cli/internal/config/set"
+	"github.com/supabase/cli/internal/config/snippet"
+	"github.com/supabase/cli/internal/config/updates"
+	"github.com/supabase/cli/internal/config/validate"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+var (
+	configCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "config",
+		Short:   "Manage local config",
+	}
+
+	keepProjectId bool
+	keepSecrets   bool
+
+	configResetCmd = &cobra.Command{
+		Use:   "reset",
+		Short: "Reset config to template defaults",
+		Long:  "Regenerate supabase/config.toml from the template defaults of the current CLI version.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reset.Run(afero.NewOsFs(), keepProjectId, keepSecrets)
+		},
+	}
+
+	configImportCmd = &cobra.Command{
+		Use:   "import",
+		Short: "Create local config from a remote project",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.GroupID = groupManagementAPI
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return importer.Run(cmd.Context(), flags.ProjectRef, afero.NewOsFs())
+		},
+	}
+
+	configCheckUpdatesCmd = &cobra.Command{
+		Use:   "check-updates",
+		Short: "List config fields added by the current CLI version",
+		Long:  "Compare supabase/config.toml against the template of the current CLI version and list new fields with their default values.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updates.Run(afero.NewOsFs(), os.Stdout)
+		},
+	}
+
+	configValidateCmd = &cobra.Command{
+		Use:   "validate",
+		Short: "Report all errors in local config",
+		Long:  "Load supabase/config.toml and list every invalid field at once, instead of stopping at the first.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return validate.Run(afero.NewOsFs())
+		},
+	}
+
+	warningsAsErrors bool
+	checkOutput      = utils.EnumFlag{
+		Allowed: []string{utils.OutputPretty, utils.OutputJson},
+		Value:   utils.OutputPretty,
+	}
+
+	configCheckCmd = &cobra.Command{
+		Use:   "check",
+		Short: "Check local config for errors without side effects",
+		Long:  "Validate supabase/config.toml without Docker or network access. Every problem is listed with its key and line, and the command exits non-zero if any errors are found.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return check.Run(afero.NewOsFs(), warningsAsErrors, checkOutput.Value, os.Stdout)
+		},
+	}
+
+	configMigrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade local config to the latest layout",
+		Long:  "Rewrite renamed or moved keys in supabase/config.toml to the layout of the current CLI version and set config_version.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return migrate.Run(afero.NewOsFs(), os.Stdout)
+		},
+	}
+
+	configOutput = utils.EnumFlag{
+		Allowed: []string{utils.OutputPretty, utils.OutputJson},
+		Value:   utils.OutputPretty,
+	}
+
+	revealSecrets bool
+
+	configGetCmd = &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the effective value of a config key",
+		Long:  "Load supabase/config.toml and print the value of a dotted key, such as api.port, after defaults and env substitution are applied. Secrets are masked unless --reveal is passed.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return get.Run(args[0], configOutput.Value, revealSecrets, os.Stdout, afero.NewOsFs())
+		},
+		Example: `  supabase config get api.port
+  supabase config get api.schemas -o json`,
+	}
+
+	configSetCmd = &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set the value of a config key",
+		Long:  "Edit a single key in supabase/config.toml, preserving comments and unrelated content. The new value is validated before writing.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return set.Run(args[0], args[1], afero.NewOsFs())
+		},
+		Example: `  supabase config set db.major_version 15
+  supabase config set api.schemas '["public", "graphql_public"]'`,
+	}
+
+	showSecrets bool
+
+	configEnvCmd = &cobra.Command{
+		Use:       "env [service]",
+		Short:     "Print the environment passed to local service containers",
+		Long:      "Render the environment variables that supabase start passes to each service container, based on supabase/config.toml. Secrets are redacted unless --show-secrets is passed.",
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: env.ServiceNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service := ""
+			if len(args) > 0 {
+				service = args[0]
+			}
+			return env.Run(service, showSecrets, os.Stdout, afero.NewOsFs())
+		},
+		Example: `  supabase config env gotrue
+  supabase config env storage --show-secrets`,
+	}
+
+	secretsOutput = utils.EnumFlag{
+		Allowed: []string{utils.OutputPretty, utils.OutputJson},
+		Value:   utils.OutputPretty,
+	}
+
+	configListSecretsCmd = &cobra.Command{
+		Use:   "list-secrets",
+		Short: "List environment variables referenced by local config",
+		Long:  "List every env() reference in supabase/config.toml with the config key that uses it, and whether the variable is set after loading .env files.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return secrets.Run(afero.NewOsFs(), secretsOutput.Value, os.Stdout)
+		},
+	}
+
+	configSchemaCmd = &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON schema of local config",
+		Long:  "Print a JSON schema of supabase/config.toml, which editors such as VS Code with Even Better TOML use to validate and autocomplete config keys.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return jsonschema.Run(os.Stdout)
+		},
+		Example: `  supabase config schema > supabase/config.schema.json`,
+	}
+
+	configTemplateCmd = &cobra.Command{
+		Use:   "template <table>",
+		Short: "Print a config snippet for a provider or function",
+		Long:  "Print a commented TOML snippet for an OAuth provider, SMS provider, S3 storage or edge function, ready to paste into supabase/config.toml. Secrets are set to env() placeholders.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return snippet.Run(args[0], os.Stdout)
+		},
+		Example: `  supabase config template auth.external.github >> supabase/config.toml
+  supabase config template functions.hello-world`,
+	}
+
+	ageRecipient string
+
+	configEncryptCmd = &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt secrets in .env file",
+		Long: `Encrypt the .env file to .env.age with a passphrase or an age public key.
+
+Secrets in .env.age are decrypted in memory when loading config and take precedence over .env.
+Set SUPABASE_AGE_PASSPHRASE or SUPABASE_AGE_IDENTITY to load them without a prompt.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return encrypt.Run(afero.NewOsFs(), ageRecipient)
+		},
+	}
+
+	configDecryptCmd = &cobra.Command{
+		Use:   "decrypt",
+		Short: "Decrypt secrets in .env.age file",
+		Long:  "Decrypt the .env.age file back to a plaintext .env file for editing.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return decrypt.Run(afero.NewOsFs())
+		},
+	}
+
+	watchConfig bool
+
+	configReloadCmd = &cobra.Command{
+		Use:   "reload",
+		Short: "Apply config changes to running containers",
+		Long:  "Recreate local containers whose config sections have changed since supabase start.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			return reload.Run(ctx, watchConfig, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	resetFlags := configResetCmd.Flags()
+	resetFlags.BoolVar(&keepProjectId, "keep-project-id", false, "Preserve the current project_id.")
+	resetFlags.BoolVar(&keepSecrets, "keep-secrets", false, "Preserve OAuth and SMS provider secrets.")
+	configCmd.AddCommand(configResetCmd)
+	configImportCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	configCmd.AddCommand(configImportCmd)
+	configCmd.AddCommand(configCheckUpdatesCmd)
+	configCmd.AddCommand(configValidateCmd)
+	checkFlags := configCheckCmd.Flags()
+	checkFlags.BoolVar(&warningsAsErrors, "warnings-as-errors", false, "Treat warnings, such as unknown keys and insecure defaults, as errors.")
+	checkFlags.VarP(&checkOutput, "output", "o", "Output format of problems.")
+	configCmd.AddCommand(configCheckCmd)
+	configCmd.AddCommand(configMigrateCmd)
+	configCmd.AddCommand(configSchemaCmd)
+	configCmd.AddCommand(configTemplateCmd)
+	configListSecretsCmd.Flags().VarP(&secretsOutput, "output", "o", "Output format of secrets.")
+	configCmd.AddCommand(configListSecretsCmd)
+	configGetCmd.Flags().VarP(&configOutput, "output", "o", "Output format of config value.")
+	configGetCmd.Flags().BoolVar(&revealSecrets, "reveal", false, "Print secret values instead of masking them.")
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configEnvCmd.Flags().BoolVar(&showSecrets, "show-secrets", false, "Print secret values instead of redacting them.")
+	configCmd.AddCommand(configEnvCmd)
+	configEncryptCmd.Flags().StringVar(&ageRecipient, "recipient", "", "Encrypt to an age public key instead of a passphrase.")
+	configCmd.AddCommand(configEncryptCmd)
+	configCmd.AddCommand(configDecryptCmd)
+	configReloadCmd.Flags().BoolVar(&watchConfig, "watch", false, "Keep watching config.toml and reload on every change.")
+	configCmd.AddCommand(configReloadCmd)
+	rootCmd.AddCommand(configCmd)
+}
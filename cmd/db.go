@@ -167,11 +167,13 @@ var (
 		},
 	}
 
+	noSeed bool
+
 	dbResetCmd = &cobra.Command{
 		Use:   "reset",
 		Short: "Resets the local database to current migrations",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return reset.Run(cmd.Context(), version, flags.DbConfig, afero.NewOsFs())
+			return reset.Run(cmd.Context(), version, noSeed, flags.DbConfig, afero.NewOsFs())
 		},
 	}
 
@@ -285,6 +287,7 @@ func init() {
 	resetFlags.Bool("local", true, "Resets the local database to current migrations.")
 	dbResetCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
 	resetFlags.StringVar(&version, "version", "", "Reset up to the specified version.")
+	resetFlags.BoolVar(&noSeed, "no-seed", false, "Skip running the seed script after reset.")
 	dbCmd.AddCommand(dbResetCmd)
 	// Build lint command
 	lintFlags := dbLintCmd.Flags()
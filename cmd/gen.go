@@ -43,10 +43,15 @@ var (
 			if err := env.Unmarshal(es, &keyNames); err != nil {
 				return err
 			}
-			cmd.GroupID = groupManagementAPI
+			if !local {
+				cmd.GroupID = groupManagementAPI
+			}
 			return cmd.Root().PersistentPreRunE(cmd, args)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if local {
+				return keys.RunLocal(keyOutput.Value, keyNames, afero.NewOsFs())
+			}
 			return keys.Run(cmd.Context(), flags.ProjectRef, keyOutput.Value, keyNames, afero.NewOsFs())
 		},
 	}
@@ -93,6 +98,8 @@ func init() {
 	genCmd.AddCommand(genTypesCmd)
 	keyFlags := genKeysCmd.Flags()
 	keyFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	keyFlags.BoolVar(&local, "local", false, "Print keys of the local development stack.")
+	genKeysCmd.MarkFlagsMutuallyExclusive("local", "project-ref")
 	keyFlags.VarP(&keyOutput, "output", "o", "Output format of key variables.")
 	keyFlags.StringSliceVar(&override, "override-name", []string{}, "Override specific variable names.")
 	genCmd.AddCommand(genKeysCmd)
@@ -2,16 +2,23 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	_init "github.com/supabase/cli/internal/init"
 	"github.com/supabase/cli/internal/utils"
+	"golang.org/x/term"
 )
 
 var (
 	createVscodeWorkspace = new(bool)
+	initForce             bool
+	initYes               bool
+	initFromRemote        string
+	initWithVscode        bool
+	initWithIntellij      bool
 
 	initCmd = &cobra.Command{
 		GroupID: groupLocalDev,
@@ -29,7 +36,18 @@ var (
 			if !cmd.Flags().Changed("with-vscode-workspace") {
 				createVscodeWorkspace = nil
 			}
-			if err := _init.Run(fsys, createVscodeWorkspace); err != nil {
+			interactive := !initYes && term.IsTerminal(int(os.Stdin.Fd()))
+			params := utils.InitParams{
+				Overwrite:  initForce || initYes,
+				FromRemote: initFromRemote,
+			}
+			if initWithVscode {
+				params.IDEs = append(params.IDEs, utils.IDEVscode)
+			}
+			if initWithIntellij {
+				params.IDEs = append(params.IDEs, utils.IDEIntellij)
+			}
+			if err := _init.Run(cmd.Context(), fsys, createVscodeWorkspace, params, interactive); err != nil {
 				return err
 			}
 
@@ -42,5 +60,10 @@ var (
 func init() {
 	flags := initCmd.Flags()
 	flags.BoolVar(createVscodeWorkspace, "with-vscode-workspace", false, "Generate VS Code workspace.")
+	flags.BoolVar(&initForce, "force", false, "Overwrite existing "+utils.ConfigPath+".")
+	flags.BoolVar(&initYes, "yes", false, "Skip prompts, overwriting existing config and using defaults for other answers.")
+	flags.StringVar(&initFromRemote, "from-remote", "", "Seed config from the settings of a remote project ref.")
+	flags.BoolVar(&initWithVscode, "with-vscode", false, "Generate .vscode/settings.json for SQL files.")
+	flags.BoolVar(&initWithIntellij, "with-intellij", false, "Generate .idea/dataSources.xml with the local database.")
 	rootCmd.AddCommand(initCmd)
 }
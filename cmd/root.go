@@ -3,8 +3,11 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
+	"log"
 	"os"
 	"os/signal"
+	"runtime/debug"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
@@ -63,6 +66,10 @@ var (
 				return errors.New("must set the --experimental flag to run this command")
 			}
 			cmd.SilenceUsage = true
+			// Override config path before searching for the project root
+			if path := viper.GetString("CONFIG"); len(path) > 0 {
+				utils.SetConfigPath(path)
+			}
 			// Change workdir
 			fsys := afero.NewOsFs()
 			if err := changeWorkDir(fsys); err != nil {
@@ -98,16 +105,73 @@ var (
 )
 
 func Execute() {
+	defer func() {
+		if r := recover(); r != nil {
+			code := utils.ReportPanic(afero.NewOsFs(), r, debug.Stack(), redactArgs(os.Args), os.Stderr)
+			utils.CloseLogger()
+			os.Exit(code)
+		}
+	}()
+	// Flushes output that is being wrapped in json before the process exits
+	defer utils.CloseLogger()
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, utils.Red(err.Error()))
+		var configErr *utils.ConfigError
+		if errors.As(err, &configErr) && err.Error() == configErr.Error() {
+			fmt.Fprintln(os.Stderr, configErr.Render())
+		} else {
+			fmt.Fprintln(os.Stderr, utils.Red(err.Error()))
+		}
+		if configErr != nil && len(utils.CmdSuggestion) == 0 {
+			utils.CmdSuggestion = configErr.Suggestion
+		}
 		if len(utils.CmdSuggestion) > 0 {
 			fmt.Fprintln(os.Stderr, utils.CmdSuggestion)
 		}
+		utils.CloseLogger()
 		os.Exit(1)
 	}
 }
 
+// Keeps command and flag names while masking all user supplied values.
+func redactArgs(args []string) []string {
+	if len(args) == 0 {
+		return args
+	}
+	cmd, rest, err := rootCmd.Find(args[1:])
+	if err != nil {
+		return []string{rootCmd.Name(), "<redacted>"}
+	}
+	result := strings.Fields(cmd.CommandPath())
+	for i := 0; i < len(rest); i++ {
+		arg := rest[i]
+		if !strings.HasPrefix(arg, "-") {
+			result = append(result, "<redacted>")
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		if index := strings.IndexByte(name, '='); index >= 0 {
+			result = append(result, arg[:len(arg)-len(name)+index]+"=<redacted>")
+			continue
+		}
+		result = append(result, arg)
+		f := cmd.Flags().Lookup(name)
+		if f == nil {
+			f = cmd.InheritedFlags().Lookup(name)
+		}
+		if f == nil && len(name) == 1 {
+			f = cmd.Flags().ShorthandLookup(name)
+		}
+		if f != nil && len(f.NoOptDefVal) == 0 && i+1 < len(rest) {
+			result = append(result, "<redacted>")
+			i++
+		}
+	}
+	return result
+}
+
 func init() {
+	// Retain recent logs for crash reports
+	log.SetOutput(io.MultiWriter(os.Stderr, utils.DebugLogs))
 	cobra.OnInitialize(func() {
 		// Allow overriding config object with automatic env
 		// Ref: https://github.com/spf13/viper/issues/761
@@ -127,9 +191,18 @@ func init() {
 	flags := rootCmd.PersistentFlags()
 	flags.Bool("debug", false, "output debug logs to stderr")
 	flags.String("workdir", "", "path to a Supabase project directory")
+	flags.String("config", "", "path to config file relative to the project directory")
+	flags.StringSlice("env-file", []string{}, "dotenv files to load secrets from, in increasing precedence")
 	flags.Bool("experimental", false, "enable experimental features")
+	flags.Bool("strict-config", false, "treat unknown config keys as errors (or set SUPABASE_CONFIG_STRICT)")
+	flags.Bool("allow-version-mismatch", false, "load config pinned to an incompatible CLI version with a warning")
+	flags.Bool("skip-jwt-verification", false, "skip verifying api keys against the jwt secret")
+	flags.Bool("allow-plaintext-secrets", false, "load secrets stored as literal values in config file")
+	flags.Bool("quiet", false, "suppress warnings")
 	flags.Var(&utils.DNSResolver, "dns-resolver", "lookup domain names using the specified resolver")
 	cobra.CheckErr(viper.BindPFlags(flags))
+	cobra.CheckErr(viper.BindEnv("CONFIG", "SUPABASE_CONFIG_PATH"))
+	cobra.CheckErr(viper.BindEnv("STRICT-CONFIG", "SUPABASE_STRICT_CONFIG", "SUPABASE_CONFIG_STRICT"))
 
 	rootCmd.SetVersionTemplate("{{.Version}}\n")
 	rootCmd.AddGroup(&cobra.Group{ID: groupLocalDev, Title: "Local Development:"})
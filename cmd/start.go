@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"os"
+	"os/signal"
 	"strings"
 
 	"github.com/spf13/afero"
@@ -15,6 +17,7 @@ var (
 	excludedContainers []string
 	ignoreHealthCheck  bool
 	preview            bool
+	watchStartConfig   bool
 
 	startCmd = &cobra.Command{
 		GroupID: groupLocalDev,
@@ -29,7 +32,11 @@ var (
 				}
 				flags.ProjectRef = projectRef
 			}
-			return start.Run(cmd.Context(), fsys, excludedContainers, ignoreHealthCheck, flags.ProjectRef, dbUrl)
+			if err := start.Run(cmd.Context(), fsys, excludedContainers, ignoreHealthCheck, flags.ProjectRef, dbUrl); err != nil || !watchStartConfig {
+				return err
+			}
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			return start.WatchConfig(ctx, fsys)
 		},
 	}
 )
@@ -40,6 +47,7 @@ func init() {
 	flags.StringSliceVarP(&excludedContainers, "exclude", "x", []string{}, "Names of containers to not start. ["+names+"]")
 	flags.BoolVar(&ignoreHealthCheck, "ignore-health-check", false, "Ignore unhealthy services and exit 0")
 	// flags.StringVar(&dbUrl, "db-url", "", "Connect to the specified database url")
+	flags.BoolVar(&watchStartConfig, "watch-config", false, "Keep running and validate config.toml on every change.")
 	flags.BoolVar(&preview, "preview", false, "Connect to feature preview branch")
 	cobra.CheckErr(flags.MarkHidden("preview"))
 	rootCmd.AddCommand(startCmd)
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/support/bundle"
+)
+
+var (
+	bundleOutput string
+
+	supportBundleCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "support-bundle",
+		Short:   "Collect diagnostics for filing an issue",
+		Long:    "Collect version, config, docker and crash diagnostics of the local setup into a zip file. All secrets are redacted.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			return bundle.Run(ctx, bundleOutput, afero.NewOsFs(), os.Stdin)
+		},
+	}
+)
+
+func init() {
+	supportBundleCmd.Flags().StringVarP(&bundleOutput, "output", "o", "supabase-support-bundle.zip", "Path to write the support bundle.")
+	rootCmd.AddCommand(supportBundleCmd)
+}
@@ -0,0 +1,103 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+type Problem struct {
+	Severity string `json:"severity"`
+	Field    string `json:"field,omitempty"`
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Message  string `json:"message"`
+	// How to fix the problem, if known
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+func Run(fsys afero.Fs, warningsAsErrors bool, format string, w io.Writer) error {
+	// Writes during loading, such as to a log file, are discarded
+	overlay := afero.NewCopyOnWriteFs(fsys, afero.NewMemMapFs())
+	warnings, err := utils.CollectWarnings(func() error {
+		return utils.ValidateAll(overlay)
+	})
+	problems := toProblems(err, fsys)
+	for _, msg := range warnings {
+		problems = append(problems, Problem{
+			Severity: severityWarning,
+			File:     utils.ConfigPath,
+			Message:  msg,
+		})
+	}
+	count := 0
+	for i := range problems {
+		if warningsAsErrors {
+			problems[i].Severity = severityError
+		}
+		if problems[i].Severity == severityError {
+			count++
+		}
+	}
+	if format == utils.OutputJson {
+		if err := utils.EncodeOutput(format, w, problems); err != nil {
+			return err
+		}
+	} else {
+		for _, p := range problems {
+			location := p.File
+			if p.Line > 0 {
+				location = fmt.Sprintf("%s:%d", p.File, p.Line)
+			}
+			fmt.Fprintf(w, "%s: %s: %s\n", location, p.Severity, p.Message)
+			if len(p.Suggestion) > 0 {
+				fmt.Fprintln(w, "  "+p.Suggestion)
+			}
+		}
+	}
+	if count > 0 {
+		return fmt.Errorf("found %d errors in %s", count, utils.ConfigPath)
+	}
+	if format != utils.OutputJson {
+		fmt.Fprintln(w, utils.Bold(utils.ConfigPath), "is valid.")
+	}
+	return nil
+}
+
+// Splits joined validation errors into problems located at their config key.
+func toProblems(err error, fsys afero.Fs) []Problem {
+	if err == nil {
+		return []Problem{}
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var result []Problem
+		for _, e := range joined.Unwrap() {
+			result = append(result, toProblems(e, fsys)...)
+		}
+		return result
+	}
+	problem := Problem{
+		Severity: severityError,
+		File:     utils.ConfigPath,
+		Message:  err.Error(),
+	}
+	var configErr *utils.ConfigError
+	var parseErr toml.ParseError
+	if errors.As(err, &configErr) {
+		problem.Field = configErr.Key
+		problem.Line = utils.FindConfigLine(fsys, configErr.Key)
+		problem.Suggestion = configErr.Suggestion
+	} else if errors.As(err, &parseErr) {
+		problem.Line = parseErr.Position.Line
+	}
+	return []Problem{problem}
+}
@@ -0,0 +1,107 @@
+package check
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestCheckCommand(t *testing.T) {
+	t.Run("accepts valid config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Run test
+		var out bytes.Buffer
+		assert.NoError(t, Run(fsys, false, utils.OutputPretty, &out))
+		// Check output
+		assert.Contains(t, out.String(), "is valid.")
+	})
+
+	t.Run("reports errors with line numbers", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"
+[api]
+port = 0
+[auth]
+jwt_expiry = 0
+`), 0644))
+		// Run test
+		var out bytes.Buffer
+		err := Run(fsys, false, utils.OutputPretty, &out)
+		// Check error
+		assert.ErrorContains(t, err, "found 2 errors")
+		assert.Contains(t, out.String(), "supabase/config.toml:3: error: Missing required field in config: api.port\n")
+		assert.Contains(t, out.String(), "supabase/config.toml:5: error: Invalid config for auth.jwt_expiry")
+	})
+
+	t.Run("promotes warnings to errors", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"
+[studio]
+port = 54321
+`), 0644))
+		// Run test
+		var out bytes.Buffer
+		assert.NoError(t, Run(fsys, false, utils.OutputPretty, &out))
+		assert.Contains(t, out.String(), "warning: api.port and studio.port both use port 54321.")
+		err := Run(fsys, true, utils.OutputPretty, &bytes.Buffer{})
+		// Check error
+		assert.ErrorContains(t, err, "found 1 errors")
+	})
+
+	t.Run("encodes problems as json", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"
+[auth]
+jwt_exipry = 3600
+`), 0644))
+		// Run test
+		var out bytes.Buffer
+		assert.NoError(t, Run(fsys, false, utils.OutputJson, &out))
+		// Check output
+		var problems []Problem
+		require.NoError(t, json.Unmarshal(out.Bytes(), &problems))
+		require.Len(t, problems, 1)
+		assert.Equal(t, severityWarning, problems[0].Severity)
+		assert.Contains(t, problems[0].Message, "Did you mean")
+	})
+
+	t.Run("encodes error key and suggestion as json", func(t *testing.T) {
+		viper.Set("auth.service_role_key", "invalid")
+		defer viper.Set("auth.service_role_key", "")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		var out bytes.Buffer
+		assert.Error(t, Run(fsys, false, utils.OutputJson, &out))
+		// Check output
+		var problems []Problem
+		require.NoError(t, json.Unmarshal(out.Bytes(), &problems))
+		require.Len(t, problems, 1)
+		assert.Equal(t, "auth.service_role_key", problems[0].Field)
+		assert.Contains(t, problems[0].Suggestion, "SUPABASE_AUTH_SERVICE_ROLE_KEY")
+	})
+
+	t.Run("reports parse errors", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte("project_id = \"test\"\nport = = 1\n"), 0644))
+		// Run test
+		var out bytes.Buffer
+		err := Run(fsys, false, utils.OutputPretty, &out)
+		// Check error
+		assert.ErrorContains(t, err, "found 1 errors")
+		assert.Contains(t, out.String(), "supabase/config.toml:2: error:")
+	})
+}
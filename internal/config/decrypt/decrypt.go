@@ -0,0 +1,40 @@
+package decrypt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(fsys afero.Fs) error {
+	if _, err := fsys.Stat(utils.EnvPath); err == nil {
+		return fmt.Errorf("%s already exists. Remove it before decrypting.", utils.Bold(utils.EnvPath))
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	f, err := fsys.Open(utils.EncryptedEnvPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s not found. Have you run %s?", utils.Bold(utils.EncryptedEnvPath), utils.Aqua("supabase config encrypt"))
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+	decrypted, err := utils.DecryptEnv(f)
+	if err != nil {
+		return err
+	}
+	contents, err := io.ReadAll(decrypted)
+	if err != nil {
+		return err
+	}
+	// Plaintext secrets should only be readable by the current user
+	if err := afero.WriteFile(fsys, utils.EnvPath, contents, 0600); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Decrypted", utils.Bold(utils.EncryptedEnvPath), "to", utils.Bold(utils.EnvPath)+".")
+	return nil
+}
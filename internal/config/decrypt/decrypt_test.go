@@ -0,0 +1,75 @@
+package decrypt
+
+import (
+	"bytes"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func encryptEnv(t *testing.T, fsys afero.Fs, contents string, recipient age.Recipient) {
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipient)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(contents))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, afero.WriteFile(fsys, utils.EncryptedEnvPath, buf.Bytes(), 0644))
+}
+
+func TestDecryptCommand(t *testing.T) {
+	t.Run("decrypts with identity from env", func(t *testing.T) {
+		identity, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		t.Setenv(utils.AgeIdentityEnv, identity.String())
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		encryptEnv(t, fsys, "# comment\nSECRET=value\n", identity.Recipient())
+		// Run test
+		assert.NoError(t, Run(fsys))
+		// Check decrypted file
+		contents, err := afero.ReadFile(fsys, utils.EnvPath)
+		assert.NoError(t, err)
+		assert.Equal(t, "# comment\nSECRET=value\n", string(contents))
+	})
+
+	t.Run("throws error on wrong passphrase", func(t *testing.T) {
+		recipient, err := age.NewScryptRecipient("correct")
+		require.NoError(t, err)
+		recipient.SetWorkFactor(10)
+		t.Setenv(utils.AgePassphraseEnv, "wrong")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		encryptEnv(t, fsys, "SECRET=value\n", recipient)
+		// Run test
+		err = Run(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to decrypt .env.age")
+		exists, err := afero.Exists(fsys, utils.EnvPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("throws error on existing env file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.EnvPath, []byte("SECRET=plain\n"), 0600))
+		// Run test
+		err := Run(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "already exists")
+	})
+
+	t.Run("throws error on missing encrypted file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "not found")
+	})
+}
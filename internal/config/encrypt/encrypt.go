@@ -0,0 +1,42 @@
+package encrypt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"filippo.io/age"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(fsys afero.Fs, recipient string) error {
+	plaintext, err := afero.ReadFile(fsys, utils.EnvPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s not found. Create it with the secrets to encrypt.", utils.Bold(utils.EnvPath))
+	} else if err != nil {
+		return err
+	}
+	r, err := utils.AgeRecipient(recipient)
+	if err != nil {
+		return err
+	}
+	var encrypted bytes.Buffer
+	w, err := age.Encrypt(&encrypted, r)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := utils.WriteFile(utils.EncryptedEnvPath, encrypted.Bytes(), fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Encrypted", utils.Bold(utils.EnvPath), "to", utils.Bold(utils.EncryptedEnvPath)+".")
+	fmt.Fprintln(os.Stderr, "You may now delete", utils.Bold(utils.EnvPath), "and commit", utils.Bold(utils.EncryptedEnvPath)+".")
+	return nil
+}
@@ -0,0 +1,76 @@
+package encrypt
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestEncryptCommand(t *testing.T) {
+	t.Run("encrypts to public key", func(t *testing.T) {
+		identity, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.EnvPath, []byte("SECRET=value\n"), 0600))
+		// Run test
+		assert.NoError(t, Run(fsys, identity.Recipient().String()))
+		// Check encrypted file
+		f, err := fsys.Open(utils.EncryptedEnvPath)
+		require.NoError(t, err)
+		defer f.Close()
+		decrypted, err := age.Decrypt(f, identity)
+		require.NoError(t, err)
+		contents, err := io.ReadAll(decrypted)
+		assert.NoError(t, err)
+		assert.Equal(t, "SECRET=value\n", string(contents))
+	})
+
+	t.Run("encrypts with passphrase from env", func(t *testing.T) {
+		t.Setenv(utils.AgePassphraseEnv, "test-passphrase")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.EnvPath, []byte("SECRET=value\n"), 0600))
+		// Run test
+		assert.NoError(t, Run(fsys, ""))
+		// Check encrypted file
+		f, err := fsys.Open(utils.EncryptedEnvPath)
+		require.NoError(t, err)
+		defer f.Close()
+		decrypted, err := utils.DecryptEnv(f)
+		require.NoError(t, err)
+		contents, err := io.ReadAll(decrypted)
+		assert.NoError(t, err)
+		assert.Equal(t, "SECRET=value\n", string(contents))
+	})
+
+	t.Run("throws error on missing env file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(fsys, "")
+		// Check error
+		assert.ErrorContains(t, err, "not found")
+		exists, err := afero.Exists(fsys, utils.EncryptedEnvPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("throws error on invalid recipient", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.EnvPath, []byte("SECRET=value\n"), 0600))
+		// Run test
+		err := Run(fsys, "age1invalid")
+		// Check error
+		assert.ErrorContains(t, err, "Invalid recipient:")
+		_, err = fsys.Stat(utils.EncryptedEnvPath)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
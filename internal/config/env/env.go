@@ -0,0 +1,88 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/start"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const redacted = "<redacted>"
+
+// Env var names that hold credentials, such as GOTRUE_SMTP_PASS and AWS_SECRET_ACCESS_KEY.
+var secretNamePattern = regexp.MustCompile(`(PASSWORD|_PASS|SECRETS?|_KEY|_KEY_ID|_KEY_BASE|_TOKEN)$`)
+
+type service struct {
+	Name string
+	Env  func(pgconn.Config) []string
+}
+
+var services = []service{
+	{"gotrue", start.GotrueEnv},
+	{"rest", func(dbConfig pgconn.Config) []string {
+		// Third-party JWKS are not fetched, to avoid network access
+		return start.PostgrestEnv(dbConfig, utils.Config.Auth.JwtVerificationKey())
+	}},
+	{"storage", start.StorageEnv},
+	{"realtime", start.RealtimeEnv},
+	{"analytics", start.LogflareEnv},
+	{"vector", start.VectorEnv},
+}
+
+func Run(name string, showSecrets bool, w io.Writer, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	selected := services
+	if len(name) > 0 {
+		selected = nil
+		for _, s := range services {
+			if s.Name == name {
+				selected = append(selected, s)
+			}
+		}
+		if len(selected) == 0 {
+			return fmt.Errorf("Unknown service: %s. Valid services are: %s", name, strings.Join(ServiceNames(), ", "))
+		}
+	}
+	dbConfig := start.LocalDbConfig()
+	for i, s := range selected {
+		if len(selected) > 1 {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintln(w, "# "+s.Name)
+		}
+		env := s.Env(dbConfig)
+		sort.Strings(env)
+		for _, line := range env {
+			if !showSecrets {
+				line = redact(line)
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+	return nil
+}
+
+func ServiceNames() []string {
+	result := make([]string, len(services))
+	for i, s := range services {
+		result[i] = s.Name
+	}
+	return result
+}
+
+func redact(line string) string {
+	name, value, _ := strings.Cut(line, "=")
+	if len(value) > 0 && secretNamePattern.MatchString(name) {
+		return name + "=" + redacted
+	}
+	return utils.RedactSecrets(line)
+}
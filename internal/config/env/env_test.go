@@ -0,0 +1,63 @@
+package env
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestEnvCommand(t *testing.T) {
+	t.Setenv("GITHUB_SECRET", "this is cool")
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"
+[auth.external.github]
+enabled = true
+client_id = "hello"
+secret = "env(GITHUB_SECRET)"
+`), 0644))
+
+	t.Run("prints service env with secrets redacted", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		assert.NoError(t, Run("gotrue", false, &out, fsys))
+		// Check output
+		assert.Contains(t, out.String(), "GOTRUE_SITE_URL=http://localhost:3000\n")
+		assert.Contains(t, out.String(), "GOTRUE_EXTERNAL_GITHUB_CLIENT_ID=hello\n")
+		assert.Contains(t, out.String(), "GOTRUE_EXTERNAL_GITHUB_SECRET=<redacted>\n")
+		assert.Contains(t, out.String(), "GOTRUE_JWT_SECRET=<redacted>\n")
+		assert.Contains(t, out.String(), "GOTRUE_DB_DATABASE_URL=postgresql://supabase_auth_admin:<redacted>@supabase_db_test:5432/postgres\n")
+		assert.Contains(t, out.String(), "GOTRUE_SECURITY_REFRESH_TOKEN_ROTATION_ENABLED=true\n")
+		assert.NotContains(t, out.String(), "this is cool")
+	})
+
+	t.Run("shows secrets when requested", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		assert.NoError(t, Run("gotrue", true, &out, fsys))
+		// Check output
+		assert.Contains(t, out.String(), "GOTRUE_EXTERNAL_GITHUB_SECRET=this is cool\n")
+	})
+
+	t.Run("prints all services", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		assert.NoError(t, Run("", false, &out, fsys))
+		// Check output
+		for _, name := range ServiceNames() {
+			assert.Contains(t, out.String(), "# "+name+"\n")
+		}
+		assert.Contains(t, out.String(), "PGRST_DB_SCHEMAS=public,storage,graphql_public\n")
+	})
+
+	t.Run("throws error on unknown service", func(t *testing.T) {
+		// Run test
+		err := Run("kong", false, &bytes.Buffer{}, fsys)
+		// Check error
+		assert.EqualError(t, err, "Unknown service: kong. Valid services are: gotrue, rest, storage, realtime, analytics, vector")
+	})
+}
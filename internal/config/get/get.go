@@ -0,0 +1,74 @@
+package get
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const masked = "***"
+
+func Run(key, format string, reveal bool, w io.Writer, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	value, err := utils.GetConfigKey(utils.Config, key)
+	if err != nil {
+		return err
+	}
+	decoded, err := toTomlValue(value)
+	if err != nil {
+		return err
+	}
+	if !reveal {
+		decoded = maskSecrets(key, decoded)
+	}
+	if format == utils.OutputJson {
+		return utils.EncodeOutput(format, w, decoded)
+	}
+	switch v := decoded.(type) {
+	case map[string]interface{}:
+		return toml.NewEncoder(w).Encode(v)
+	case []interface{}:
+		for _, item := range v {
+			fmt.Fprintln(w, item)
+		}
+		return nil
+	default:
+		_, err := fmt.Fprintln(w, v)
+		return err
+	}
+}
+
+// Round trips the value through TOML so that tables are keyed by their toml tags and custom
+// types, such as sizes and durations, are rendered as they appear in config.toml.
+func toTomlValue(value any) (any, error) {
+	var buf strings.Builder
+	if err := toml.NewEncoder(&buf).Encode(map[string]any{"value": value}); err != nil {
+		return nil, err
+	}
+	var decoded map[string]any
+	if _, err := toml.Decode(buf.String(), &decoded); err != nil {
+		return nil, err
+	}
+	return decoded["value"], nil
+}
+
+// Replaces non-empty secrets, including those nested in tables, with a fixed placeholder.
+func maskSecrets(key string, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = maskSecrets(key+"."+k, item)
+		}
+	case string:
+		if len(v) > 0 && utils.IsSecretConfigKey(key) {
+			return masked
+		}
+	}
+	return value
+}
@@ -0,0 +1,98 @@
+package get
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestGetCommand(t *testing.T) {
+	t.Setenv("GITHUB_SECRET", "this is cool")
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"
+[api]
+schemas = ["public", "app"]
+[storage]
+file_size_limit = "5MB"
+[auth.external.github]
+enabled = true
+client_id = "hello"
+secret = "env(GITHUB_SECRET)"
+`), 0644))
+
+	t.Run("prints scalar value", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		assert.NoError(t, Run("api.port", utils.OutputPretty, false, &out, fsys))
+		// Check output
+		assert.Equal(t, "54321\n", out.String())
+	})
+
+	t.Run("prints size as in config", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		assert.NoError(t, Run("storage.file_size_limit", utils.OutputPretty, false, &out, fsys))
+		// Check output
+		assert.Equal(t, "5MiB\n", out.String())
+	})
+
+	t.Run("encodes list as json", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		assert.NoError(t, Run("api.schemas", utils.OutputJson, false, &out, fsys))
+		// Check output
+		assert.JSONEq(t, `["public", "app", "storage"]`, out.String())
+	})
+
+	t.Run("encodes table as json", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		assert.NoError(t, Run("api.rate_limiting", utils.OutputJson, false, &out, fsys))
+		// Check output
+		assert.JSONEq(t, `{"enabled": false, "requests_per_second": 10.0, "key": "ip"}`, out.String())
+	})
+
+	t.Run("masks secret value", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		assert.NoError(t, Run("auth.external.github.secret", utils.OutputPretty, false, &out, fsys))
+		// Check output
+		assert.Equal(t, "***\n", out.String())
+	})
+
+	t.Run("masks secret in table", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		assert.NoError(t, Run("auth.external.github", utils.OutputJson, false, &out, fsys))
+		// Check output
+		assert.Contains(t, out.String(), `"secret": "***"`)
+		assert.Contains(t, out.String(), `"client_id": "hello"`)
+	})
+
+	t.Run("reveals secret value", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		assert.NoError(t, Run("auth.external.github.secret", utils.OutputPretty, true, &out, fsys))
+		// Check output
+		assert.Equal(t, "this is cool\n", out.String())
+	})
+
+	t.Run("throws error on unknown key", func(t *testing.T) {
+		// Run test
+		err := Run("api.unknown", utils.OutputPretty, false, &bytes.Buffer{}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Unknown config key: api.unknown")
+	})
+
+	t.Run("throws error on secret key", func(t *testing.T) {
+		// Run test
+		err := Run("auth.jwt_secret", utils.OutputPretty, false, &bytes.Buffer{}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Set SUPABASE_AUTH_JWT_SECRET in")
+	})
+}
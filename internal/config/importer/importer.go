@@ -0,0 +1,17 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, projectRef string, fsys afero.Fs) error {
+	if err := utils.ImportConfig(ctx, projectRef, fsys); err != nil {
+		return err
+	}
+	fmt.Println("Imported config of project " + utils.Aqua(projectRef) + " to " + utils.Bold(utils.ConfigPath) + ".")
+	return nil
+}
@@ -0,0 +1,145 @@
+package importer
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestImportCommand(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("imports remote settings", func(t *testing.T) {
+		t.Setenv("SUPABASE_AUTH_EMAIL_SMTP_PASS", "smtp-secret")
+		t.Setenv("SUPABASE_AUTH_EXTERNAL_GITHUB_SECRET", "github-secret")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects").
+			Reply(http.StatusOK).
+			JSON([]api.ProjectResponse{{
+				Id:       project,
+				Database: &api.DatabaseResponse{Version: "15.1.0.117"},
+			}})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/postgrest").
+			Reply(http.StatusOK).
+			JSON(api.PostgrestConfigWithJWTSecretResponse{
+				DbSchema:          "public, graphql_public, private",
+				DbExtraSearchPath: "public,extensions",
+				MaxRows:           500,
+			})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/auth").
+			Reply(http.StatusOK).
+			JSON(map[string]interface{}{
+				"site_url":                  "https://example.com",
+				"jwt_exp":                   7200,
+				"disable_signup":            true,
+				"external_github_enabled":   true,
+				"external_github_client_id": "github-client",
+				"external_github_secret":    "remote-github-secret",
+				"smtp_host":                 "smtp.sendgrid.net",
+				"smtp_port":                 "587",
+				"smtp_user":                 "apikey",
+				"smtp_pass":                 "remote-smtp-password",
+				"smtp_admin_email":          "admin@example.com",
+				"smtp_sender_name":          "Example",
+			})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/storage").
+			Reply(http.StatusOK).
+			JSON(api.StorageConfigResponse{
+				FileSizeLimit: 100 << 20,
+				Features: api.StorageFeatures{
+					ImageTransformation: api.StorageFeatureImageTransformation{Enabled: true},
+				},
+			})
+		// Run test
+		assert.NoError(t, Run(context.Background(), project, fsys))
+		// Validate generated config.toml
+		contents, err := afero.ReadFile(fsys, utils.ConfigPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), `project_id = "`+project+`"`)
+		assert.Contains(t, string(contents), `schemas = ["public", "graphql_public", "private"]`)
+		assert.Contains(t, string(contents), `pass = "env(SUPABASE_AUTH_EMAIL_SMTP_PASS)"`)
+		assert.Contains(t, string(contents), `secret = "env(SUPABASE_AUTH_EXTERNAL_GITHUB_SECRET)"`)
+		assert.NotContains(t, string(contents), "remote-smtp-password")
+		assert.NotContains(t, string(contents), "remote-github-secret")
+		example, err := afero.ReadFile(fsys, utils.EnvExamplePath)
+		require.NoError(t, err)
+		assert.Contains(t, string(example), "\nSUPABASE_AUTH_EMAIL_SMTP_PASS=\n")
+		assert.Contains(t, string(example), "\nSUPABASE_AUTH_EXTERNAL_GITHUB_SECRET=\n")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		// Check that config is valid
+		require.NoError(t, utils.LoadConfigFS(fsys))
+		assert.Equal(t, project, utils.Config.ProjectId)
+		assert.Equal(t, uint(15), utils.Config.Db.MajorVersion)
+		assert.Equal(t, []string{"public", "extensions"}, utils.Config.Api.ExtraSearchPath)
+		assert.Equal(t, uint(500), utils.Config.Api.MaxRows)
+		assert.Equal(t, "https://example.com", utils.Config.Auth.SiteUrl)
+		assert.Equal(t, uint(7200), utils.Config.Auth.JwtExpiry)
+		assert.False(t, utils.Config.Auth.EnableSignup)
+		assert.Equal(t, "github-client", utils.Config.Auth.External["github"].ClientId)
+		assert.Equal(t, "github-secret", utils.Config.Auth.External["github"].Secret)
+		assert.Equal(t, "smtp.sendgrid.net", utils.Config.Auth.Email.Smtp.Host)
+		assert.Equal(t, uint(587), utils.Config.Auth.Email.Smtp.Port)
+		assert.Equal(t, "admin@example.com", utils.Config.Auth.Email.Smtp.AdminEmail)
+		assert.Equal(t, "smtp-secret", utils.Config.Auth.Email.Smtp.Pass)
+		assert.EqualValues(t, 100<<20, utils.Config.Storage.FileSizeLimit)
+		assert.True(t, utils.Config.Storage.ImageTransformation.Enabled)
+	})
+
+	t.Run("throws error on existing config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		err := Run(context.Background(), project, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Config file already exists")
+	})
+
+	t.Run("throws error on service unavailable", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects").
+			Reply(http.StatusOK).
+			JSON([]api.ProjectResponse{})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/postgrest").
+			Reply(http.StatusOK).
+			JSON(api.PostgrestConfigWithJWTSecretResponse{DbSchema: "public"})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/auth").
+			Reply(http.StatusOK).
+			JSON(api.AuthConfigResponse{})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/storage").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), project, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error retrieving storage config")
+		exists, err := afero.Exists(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
@@ -0,0 +1,13 @@
+package jsonschema
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(w io.Writer) error {
+	_, err := fmt.Fprint(w, utils.ConfigSchemaJson)
+	return err
+}
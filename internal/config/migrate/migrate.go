@@ -0,0 +1,28 @@
+package migrate
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(fsys afero.Fs, w io.Writer) error {
+	if err := utils.AssertSupabaseCliIsSetUpFS(fsys); err != nil {
+		return err
+	}
+	changes, err := utils.MigrateConfig(fsys)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(w, utils.Bold(utils.ConfigPath)+" is already at config_version", utils.CurrentConfigVersion)
+		return nil
+	}
+	fmt.Fprintln(w, "Migrated "+utils.Bold(utils.ConfigPath)+":")
+	for _, line := range changes {
+		fmt.Fprintln(w, "~ "+line)
+	}
+	return nil
+}
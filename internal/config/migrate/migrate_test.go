@@ -0,0 +1,46 @@
+package migrate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestMigrateCommand(t *testing.T) {
+	t.Run("stamps config version", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"
+`), 0644))
+		// Run test
+		var out bytes.Buffer
+		assert.NoError(t, Run(fsys, &out))
+		// Check output
+		assert.Contains(t, out.String(), "~ Set config_version to 2")
+		contents, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, "config_version = 2\nproject_id = \"test\"\n", string(contents))
+	})
+
+	t.Run("skips up to date config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Run test
+		var out bytes.Buffer
+		assert.NoError(t, Run(fsys, &out))
+		// Check output
+		assert.Contains(t, out.String(), "is already at config_version 2")
+	})
+
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Run test
+		err := Run(afero.NewMemMapFs(), &bytes.Buffer{})
+		// Check error
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,71 @@
+package reload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/start"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var errNotStarted = errors.New("Applied config not found. Restart local containers with " + utils.Aqua("supabase stop && supabase start") + " to enable reloading.")
+
+func Run(ctx context.Context, watch bool, fsys afero.Fs) error {
+	if err := ReloadConfig(ctx, fsys); err != nil {
+		return err
+	}
+	if !watch {
+		return nil
+	}
+	return watchConfig(ctx, fsys)
+}
+
+// Loads the config that running containers were started with and the current config on disk,
+// then recreates services whose config sections have changed.
+func ReloadConfig(ctx context.Context, fsys afero.Fs) error {
+	contents, err := afero.ReadFile(fsys, utils.AppliedConfigPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return errNotStarted
+	} else if err != nil {
+		return err
+	}
+	applied := afero.NewCopyOnWriteFs(fsys, afero.NewMemMapFs())
+	if err := afero.WriteFile(applied, utils.ConfigPath, contents, 0644); err != nil {
+		return err
+	}
+	if err := utils.LoadConfigFS(applied); err != nil {
+		return err
+	}
+	if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+		return err
+	}
+	old := utils.Config
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	diff := utils.ConfigDiff(old, utils.Config)
+	if len(diff) == 0 {
+		fmt.Fprintln(os.Stderr, "No config changes to reload.")
+		return nil
+	}
+	sections := make([]string, 0, len(diff))
+	for name := range diff {
+		sections = append(sections, name)
+	}
+	sort.Strings(sections)
+	fmt.Fprintln(os.Stderr, "Changed config sections:", sections)
+	return start.Reconfigure(ctx, fsys, sections)
+}
+
+func watchConfig(ctx context.Context, fsys afero.Fs) error {
+	return utils.WatchConfig(ctx, func() {
+		// Invalid config should not stop the watcher
+		if err := ReloadConfig(ctx, fsys); err != nil {
+			fmt.Fprintln(os.Stderr, utils.Red(err.Error()))
+		}
+	})
+}
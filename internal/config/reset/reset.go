@@ -0,0 +1,19 @@
+package reset
+
+import (
+	"fmt"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(fsys afero.Fs, keepProjectId, keepSecrets bool) error {
+	if err := utils.AssertSupabaseCliIsSetUpFS(fsys); err != nil {
+		return err
+	}
+	if err := utils.ResetConfig(fsys, keepProjectId, keepSecrets); err != nil {
+		return err
+	}
+	fmt.Println("Reset " + utils.Bold(utils.ConfigPath) + " to template defaults.")
+	return nil
+}
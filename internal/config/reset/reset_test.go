@@ -0,0 +1,36 @@
+package reset
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestResetCommand(t *testing.T) {
+	t.Run("resets config to defaults", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "drifted"
+[api]
+port = 1234
+`), 0644))
+		// Run test
+		assert.NoError(t, Run(fsys, true, false))
+		// Validate generated config.toml
+		contents, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Contains(t, string(contents), `project_id = "drifted"`)
+		assert.Contains(t, string(contents), "port = 54321")
+		assert.NotContains(t, string(contents), "port = 1234")
+	})
+
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.Error(t, Run(fsys, false, false))
+	})
+}
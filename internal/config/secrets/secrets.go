@@ -0,0 +1,48 @@
+package secrets
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(fsys afero.Fs, format string, w io.Writer) error {
+	if err := utils.AssertSupabaseCliIsSetUpFS(fsys); err != nil {
+		return err
+	}
+	// Unresolved config keeps the env() references that full loading would substitute
+	raw, err := utils.ReadConfigFS(fsys)
+	if err != nil {
+		return err
+	}
+	if err := utils.LoadSecretEnv(fsys); err != nil {
+		return err
+	}
+	refs := utils.ListSecrets(raw)
+	if format == utils.OutputJson {
+		if refs == nil {
+			refs = []utils.SecretRef{}
+		}
+		return utils.EncodeOutput(format, w, refs)
+	}
+	if len(refs) == 0 {
+		fmt.Fprintln(w, "No env() references found in "+utils.Bold(utils.ConfigPath))
+		return nil
+	}
+	table := `|NAME|KEY|STATUS|
+|-|-|-|
+`
+	for _, ref := range refs {
+		status := "set"
+		if !ref.Set && ref.HasDefault {
+			status = "default"
+		} else if !ref.Set {
+			status = "missing"
+		}
+		table += fmt.Sprintf("|`%s`|`%s`|%s|\n", ref.Name, ref.Key, status)
+	}
+	return list.RenderTable(table)
+}
@@ -0,0 +1,57 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestListSecretsCommand(t *testing.T) {
+	t.Run("loads variables from env file", func(t *testing.T) {
+		t.Setenv("GITHUB_SECRET", "")
+		defer os.Unsetenv("GITHUB_CLIENT_ID")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"
+[auth.external.github]
+enabled = true
+client_id = "env(GITHUB_CLIENT_ID)"
+secret = "env(GITHUB_SECRET)"
+`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, utils.EnvPath, []byte("GITHUB_CLIENT_ID=hello\n"), 0644))
+		// Run test
+		var out bytes.Buffer
+		assert.NoError(t, Run(fsys, utils.OutputJson, &out))
+		// Check output
+		var refs []utils.SecretRef
+		require.NoError(t, json.Unmarshal(out.Bytes(), &refs))
+		assert.Equal(t, []utils.SecretRef{
+			{Name: "GITHUB_CLIENT_ID", Key: "auth.external.github.client_id", Set: true},
+			{Name: "GITHUB_SECRET", Key: "auth.external.github.secret"},
+		}, refs)
+	})
+
+	t.Run("prints message without references", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		var out bytes.Buffer
+		assert.NoError(t, Run(fsys, utils.OutputPretty, &out))
+		// Check output
+		assert.Contains(t, out.String(), "No env() references found")
+	})
+
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Run test
+		err := Run(afero.NewMemMapFs(), utils.OutputPretty, &bytes.Buffer{})
+		// Check error
+		assert.Error(t, err)
+	})
+}
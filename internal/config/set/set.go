@@ -0,0 +1,20 @@
+package set
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(key, value string, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if err := utils.SetConfigValue(key, value, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Updated", utils.Aqua(key), "in", utils.Bold(utils.ConfigPath)+".")
+	return nil
+}
@@ -0,0 +1,75 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestSetCommand(t *testing.T) {
+	t.Run("updates value preserving comments", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Run test
+		assert.NoError(t, Run("db.major_version", "14", fsys))
+		assert.NoError(t, Run("api.schemas", `["public", "app"]`, fsys))
+		assert.NoError(t, Run("studio.api_url", "http://127.0.0.1", fsys))
+		// Check config
+		contents, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Contains(t, string(contents), "major_version = 14\n")
+		assert.Contains(t, string(contents), `schemas = ["public", "app"]`)
+		assert.Contains(t, string(contents), `api_url = "http://127.0.0.1"`)
+		assert.Contains(t, string(contents), "# The database major version to use.")
+	})
+
+	t.Run("quotes string values", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		assert.NoError(t, Run("project_id", "15", fsys))
+		// Check config
+		contents, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, `project_id = "15"`, string(contents))
+	})
+
+	t.Run("throws error on invalid value", func(t *testing.T) {
+		original := []byte(`project_id = "test"`)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, original, 0644))
+		// Run test
+		err := Run("db.major_version", "12", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for db.major_version: Postgres version 12.x is unsupported.")
+		contents, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, original, contents)
+	})
+
+	t.Run("throws error on secret key", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		err := Run("auth.jwt_secret", "secret", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Set SUPABASE_AUTH_JWT_SECRET in")
+	})
+
+	t.Run("throws error on literal provider secret", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		err := Run("auth.external.github.secret", "literal", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "auth.external.github.secret is a secret")
+	})
+}
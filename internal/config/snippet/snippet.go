@@ -0,0 +1,18 @@
+package snippet
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(key string, w io.Writer) error {
+	snippet := utils.GenerateProviderTemplate(key)
+	if len(snippet) == 0 {
+		return fmt.Errorf("No template for %s. Try one of: %s", utils.Aqua(key), strings.Join(utils.ProviderTemplateKeys(), ", "))
+	}
+	_, err := fmt.Fprint(w, snippet)
+	return err
+}
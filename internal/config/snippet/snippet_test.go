@@ -0,0 +1,28 @@
+package snippet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateCommand(t *testing.T) {
+	t.Run("prints provider snippet", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		assert.NoError(t, Run("auth.external.github", &out))
+		// Check output
+		assert.Contains(t, out.String(), "[auth.external.github]\n")
+	})
+
+	t.Run("throws error on unsupported table", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := Run("auth.sms.unknown", &out)
+		// Check error
+		assert.ErrorContains(t, err, "No template for")
+		assert.ErrorContains(t, err, "auth.sms.twilio")
+		assert.Empty(t, out.String())
+	})
+}
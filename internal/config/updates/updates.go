@@ -0,0 +1,29 @@
+package updates
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(fsys afero.Fs, w io.Writer) error {
+	if err := utils.AssertSupabaseCliIsSetUpFS(fsys); err != nil {
+		return err
+	}
+	updates, err := utils.CheckConfigUpdates(fsys, utils.Version)
+	if err != nil {
+		return err
+	}
+	if len(updates) == 0 {
+		fmt.Fprintln(w, utils.Bold(utils.ConfigPath)+" is up to date with CLI "+utils.Version+".")
+		return nil
+	}
+	fmt.Fprintln(w, "New config fields available in CLI "+utils.Version+":")
+	for _, line := range updates {
+		fmt.Fprintln(w, "+ "+line)
+	}
+	fmt.Fprintln(w, "\nThese fields use their default values. Add them to "+utils.Bold(utils.ConfigPath)+" to customise.")
+	return nil
+}
@@ -0,0 +1,48 @@
+package updates
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestCheckUpdatesCommand(t *testing.T) {
+	t.Run("lists new fields with defaults", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"
+[api]
+enabled = true
+port = 54321
+`), 0644))
+		var out bytes.Buffer
+		// Run test
+		assert.NoError(t, Run(fsys, &out))
+		// Check output
+		assert.Contains(t, out.String(), "+ api.schemas = [\"public\", \"storage\", \"graphql_public\"]\n")
+		assert.Contains(t, out.String(), "+ api.rate_limiting.enabled = false\n")
+		assert.Contains(t, out.String(), "+ api.rate_limiting.requests_per_second = 10\n")
+		assert.NotContains(t, out.String(), "api.port")
+		assert.NotContains(t, out.String(), "project_id")
+	})
+
+	t.Run("reports up to date config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
+		var out bytes.Buffer
+		// Run test
+		assert.NoError(t, Run(fsys, &out))
+		// Check output
+		assert.Contains(t, out.String(), "is up to date")
+	})
+
+	t.Run("throws error on missing config", func(t *testing.T) {
+		err := Run(afero.NewMemMapFs(), &bytes.Buffer{})
+		assert.Error(t, err)
+	})
+}
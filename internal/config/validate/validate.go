@@ -0,0 +1,17 @@
+package validate
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(fsys afero.Fs) error {
+	if err := utils.ValidateAll(fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, utils.Bold(utils.ConfigPath), "is valid.")
+	return nil
+}
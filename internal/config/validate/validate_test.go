@@ -0,0 +1,46 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestValidateCommand(t *testing.T) {
+	t.Run("accepts valid config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
+		// Run test
+		assert.NoError(t, Run(fsys))
+	})
+
+	t.Run("reports every invalid section", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"
+[api]
+port = 0
+[realtime]
+max_concurrent_users = 0
+[auth]
+jwt_expiry = 0
+`), 0644))
+		// Run test
+		err := Run(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Missing required field in config: api.port")
+		assert.ErrorContains(t, err, "Invalid config for realtime.max_concurrent_users")
+		assert.ErrorContains(t, err, "Invalid config for auth.jwt_expiry")
+	})
+
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Run test
+		err := Run(afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "cannot read config")
+	})
+}
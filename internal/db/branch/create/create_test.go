@@ -90,7 +90,7 @@ func TestCreateCommand(t *testing.T) {
 	t.Run("throws error on stopped db", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := &afero.MemMapFs{}
-		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
 		defer gock.OffAll()
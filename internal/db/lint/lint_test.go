@@ -30,7 +30,7 @@ var dbConfig = pgconn.Config{
 func TestLintCommand(t *testing.T) {
 	// Setup in-memory fs
 	fsys := afero.NewMemMapFs()
-	require.NoError(t, utils.WriteConfig(fsys, false))
+	require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
 	// Setup mock docker
 	require.NoError(t, apitest.MockDocker(utils.Docker))
 	defer gock.OffAll()
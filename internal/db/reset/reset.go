@@ -39,7 +39,7 @@ var (
 	dropObjects string
 )
 
-func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+func Run(ctx context.Context, version string, noSeed bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	if len(version) > 0 {
 		if _, err := strconv.Atoi(version); err != nil {
 			return repair.ErrInvalidVersion
@@ -52,6 +52,9 @@ func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.F
 		if shouldReset := utils.PromptYesNo("Confirm resetting the remote database?", true, os.Stdin); !shouldReset {
 			return context.Canceled
 		}
+		if noSeed {
+			utils.Config.Db.Seed.Enabled = false
+		}
 		return resetRemote(ctx, version, config, fsys, options...)
 	}
 
@@ -64,6 +67,9 @@ func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.F
 			return err
 		}
 	}
+	if noSeed {
+		utils.Config.Db.Seed.Enabled = false
+	}
 
 	// Reset postgres database because extensions (pg_cron, pg_net) require postgres
 	if err := resetDatabase(ctx, version, fsys, options...); err != nil {
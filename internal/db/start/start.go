@@ -47,7 +47,20 @@ func Run(ctx context.Context, fsys afero.Fs) error {
 	return err
 }
 
+// Returns the server settings from config as postgres command line flags.
+func serverSettings() []string {
+	settings := []string{fmt.Sprintf("--max_connections=%d", utils.Config.Db.MaxConnections)}
+	if timeout := utils.Config.Db.Timeouts.StatementTimeout; timeout > 0 {
+		settings = append(settings, fmt.Sprintf("--statement_timeout=%d", timeout))
+	}
+	if timeout := utils.Config.Db.Timeouts.IdleInTransactionSessionTimeout; timeout > 0 {
+		settings = append(settings, fmt.Sprintf("--idle_in_transaction_session_timeout=%d", timeout))
+	}
+	return settings
+}
+
 func NewContainerConfig() container.Config {
+	settings := serverSettings()
 	config := container.Config{
 		Image: utils.DbImage,
 		Env: []string{
@@ -64,7 +77,7 @@ func NewContainerConfig() container.Config {
 			Timeout:  2 * time.Second,
 			Retries:  3,
 		},
-		Entrypoint: []string{"sh", "-c", `cat <<'EOF' > /etc/postgresql.schema.sql && docker-entrypoint.sh postgres -D /etc/postgresql
+		Entrypoint: []string{"sh", "-c", `cat <<'EOF' > /etc/postgresql.schema.sql && docker-entrypoint.sh postgres -D /etc/postgresql ` + strings.Join(settings, " ") + `
 ` + initialSchema + `
 EOF
 `},
@@ -75,6 +88,7 @@ EOF
 			// Ref: https://postgrespro.com/list/thread-id/2448092
 			"-c", `search_path="$user",public,extensions`,
 		}
+		config.Cmd = append(config.Cmd, settings...)
 	}
 	return config
 }
@@ -172,5 +186,21 @@ func SetupDatabase(ctx context.Context, conn *pgx.Conn, host string, w io.Writer
 	if err := initSchema(ctx, conn, host, w); err != nil {
 		return err
 	}
-	return push.CreateCustomRoles(ctx, conn, w, fsys)
+	if err := push.CreateCustomRoles(ctx, conn, w, fsys); err != nil {
+		return err
+	}
+	return CreateExtensions(ctx, conn, w)
+}
+
+// Creates extensions listed in db.extensions, along with any extensions they depend on.
+func CreateExtensions(ctx context.Context, conn *pgx.Conn, w io.Writer) error {
+	if len(utils.Config.Db.Extensions) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w, "Creating extensions "+strings.Join(utils.Config.Db.Extensions, ", ")+"...")
+	var sql strings.Builder
+	for _, name := range utils.Config.Db.Extensions {
+		sql.WriteString("CREATE EXTENSION IF NOT EXISTS " + pgx.Identifier{name}.Sanitize() + " CASCADE;\n")
+	}
+	return apply.BatchExecDDL(ctx, conn, strings.NewReader(sql.String()))
 }
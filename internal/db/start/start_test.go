@@ -10,6 +10,7 @@ import (
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/volume"
+	"github.com/jackc/pgconn"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,6 +50,31 @@ func TestInitBranch(t *testing.T) {
 	})
 }
 
+func TestNewContainerConfig(t *testing.T) {
+	t.Run("caps max connections", func(t *testing.T) {
+		utils.Config.Db.MajorVersion = 15
+		utils.Config.Db.MaxConnections = 50
+		defer func() { utils.Config.Db.MaxConnections = 100 }()
+		// Run test
+		config := NewContainerConfig()
+		// Check result
+		assert.Contains(t, config.Entrypoint[2], "docker-entrypoint.sh postgres -D /etc/postgresql --max_connections=50\n")
+		assert.Contains(t, config.Cmd, "--max_connections=50")
+	})
+
+	t.Run("sets server timeouts", func(t *testing.T) {
+		utils.Config.Db.MajorVersion = 15
+		utils.Config.Db.Timeouts.StatementTimeout = 30000
+		defer func() { utils.Config.Db.Timeouts.StatementTimeout = 0 }()
+		// Run test
+		config := NewContainerConfig()
+		// Check result
+		assert.Contains(t, config.Entrypoint[2], "--max_connections=100 --statement_timeout=30000\n")
+		assert.Contains(t, config.Cmd, "--statement_timeout=30000")
+		assert.NotContains(t, config.Entrypoint[2], "idle_in_transaction_session_timeout")
+	})
+}
+
 func TestStartDatabase(t *testing.T) {
 	teardown := func() {
 		utils.Containers = []string{}
@@ -181,7 +207,7 @@ func TestStartCommand(t *testing.T) {
 	t.Run("throws error on missing docker", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
 		defer gock.OffAll()
@@ -201,7 +227,7 @@ func TestStartCommand(t *testing.T) {
 	t.Run("exits if already started", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
 		defer gock.OffAll()
@@ -224,7 +250,7 @@ func TestStartCommand(t *testing.T) {
 	t.Run("throws error on start failure", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
 		defer gock.OffAll()
@@ -285,6 +311,29 @@ func TestSetupDatabase(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("creates configured extensions", func(t *testing.T) {
+		utils.Config.Db.Extensions = []string{"uuid-ossp", "vector"}
+		defer func() {
+			utils.Config.Db.Extensions = nil
+		}()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(`CREATE EXTENSION IF NOT EXISTS "uuid-ossp" CASCADE`).
+			Reply("CREATE EXTENSION").
+			Query(`CREATE EXTENSION IF NOT EXISTS "vector" CASCADE`).
+			Reply("CREATE EXTENSION")
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		err = CreateExtensions(ctx, mock, io.Discard)
+		// Check error
+		assert.NoError(t, err)
+	})
+
 	t.Run("throws error on connect failure", func(t *testing.T) {
 		utils.Config.Db.Port = 0
 		// Run test
@@ -108,7 +108,7 @@ func TestRunCommand(t *testing.T) {
 	t.Run("throws error on missing database", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
 		gock.New(utils.Docker.DaemonHost()).
@@ -124,7 +124,7 @@ func TestRunCommand(t *testing.T) {
 	t.Run("throws error on missing tests", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
 		gock.New(utils.Docker.DaemonHost()).
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os/exec"
 	"path/filepath"
+	"sort"
 
 	"github.com/docker/go-units"
 	"github.com/spf13/afero"
@@ -53,9 +54,28 @@ func getFunctionSlugs(fsys afero.Fs) ([]string, error) {
 			slugs = append(slugs, slug)
 		}
 	}
+	// Functions with custom entrypoints may not have an index.ts
+	for slug, functionConfig := range utils.Config.Functions {
+		if len(functionConfig.Entrypoint) == 0 || !utils.FuncSlugPattern.MatchString(slug) || utils.SliceContains(slugs, slug) {
+			continue
+		}
+		if exists, err := afero.Exists(fsys, getEntrypointPath(slug)); err != nil {
+			return nil, err
+		} else if exists {
+			slugs = append(slugs, slug)
+		}
+	}
+	sort.Strings(slugs)
 	return slugs, nil
 }
 
+func getEntrypointPath(slug string) string {
+	if functionConfig, ok := utils.Config.Functions[slug]; ok && len(functionConfig.Entrypoint) > 0 {
+		return filepath.Join(utils.FunctionsDir, slug, functionConfig.Entrypoint)
+	}
+	return filepath.Join(utils.FunctionsDir, slug, "index.ts")
+}
+
 func bundleFunction(ctx context.Context, entrypointPath, importMapPath, buildScriptPath string) (*bytes.Buffer, error) {
 	denoPath, err := utils.GetDenoPath()
 	if err != nil {
@@ -120,7 +140,7 @@ func deployOne(ctx context.Context, slug, projectRef, importMapPath, buildScript
 	// 1. Ensure noVerifyJWT is not nil.
 	if noVerifyJWT == nil {
 		x := false
-		if functionConfig, ok := utils.Config.Functions[slug]; ok && !*functionConfig.VerifyJWT {
+		if functionConfig, ok := utils.Config.Functions[slug]; ok && !functionConfig.ShouldVerifyJWT() {
 			x = true
 		}
 		noVerifyJWT = &x
@@ -138,7 +158,7 @@ func deployOne(ctx context.Context, slug, projectRef, importMapPath, buildScript
 	}
 	importMapPath = resolved
 	// 2. Bundle Function.
-	entrypointPath, err := filepath.Abs(filepath.Join(utils.FunctionsDir, slug, "index.ts"))
+	entrypointPath, err := filepath.Abs(getEntrypointPath(slug))
 	if err != nil {
 		return err
 	}
@@ -300,7 +300,7 @@ func TestDeployCommand(t *testing.T) {
 	t.Run("verify_jwt param falls back to config", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
 		f, err := fsys.OpenFile("supabase/config.toml", os.O_APPEND|os.O_WRONLY, 0600)
 		require.NoError(t, err)
 		_, err = f.WriteString(`
@@ -336,7 +336,7 @@ verify_jwt = false
 	t.Run("verify_jwt flag overrides config", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
 		f, err := fsys.OpenFile("supabase/config.toml", os.O_APPEND|os.O_WRONLY, 0600)
 		require.NoError(t, err)
 		_, err = f.WriteString(`
@@ -371,6 +371,31 @@ verify_jwt = false
 	})
 }
 
+func TestGetFunctionSlugs(t *testing.T) {
+	t.Run("includes functions with custom entrypoint", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "hello", "index.ts"), []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "custom", "src", "main.tsx"), []byte{}, 0644))
+		// Setup function config
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`
+project_id = "test"
+[functions.custom]
+entrypoint = "src/main.tsx"
+[functions.missing]
+entrypoint = "main.ts"
+`), 0644))
+		require.NoError(t, utils.LoadConfigFS(fsys))
+		defer func() { utils.Config.Functions = nil }()
+		// Run test
+		slugs, err := getFunctionSlugs(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"custom", "hello"}, slugs)
+		assert.Equal(t, filepath.Join(utils.FunctionsDir, "custom", "src", "main.tsx"), getEntrypointPath("custom"))
+	})
+}
+
 func TestDeployFunction(t *testing.T) {
 	const slug = "test-func"
 	// Setup valid project ref
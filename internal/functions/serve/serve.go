@@ -9,14 +9,15 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
 	"github.com/joho/godotenv"
 	"github.com/spf13/afero"
-	"github.com/spf13/viper"
 	"github.com/supabase/cli/internal/db/start"
 	"github.com/supabase/cli/internal/utils"
 )
@@ -92,8 +93,9 @@ func ServeFunctions(ctx context.Context, envFilePath string, noVerifyJWT *bool,
 		"SUPABASE_INTERNAL_JWT_SECRET=" + utils.Config.Auth.JwtSecret,
 		fmt.Sprintf("SUPABASE_INTERNAL_HOST_PORT=%d", utils.Config.Api.Port),
 		"SUPABASE_INTERNAL_FUNCTIONS_PATH=" + dockerFuncDirPath,
+		"EDGE_RUNTIME_POLICY=" + string(utils.Config.EdgeRuntime.Policy),
 	}
-	if viper.GetBool("DEBUG") {
+	if utils.DebugEnabled() {
 		env = append(env, "SUPABASE_INTERNAL_DEBUG=true")
 	}
 	// 3. Parse custom import map
@@ -138,7 +140,7 @@ func ServeFunctions(ctx context.Context, envFilePath string, noVerifyJWT *bool,
 	var cmdString string
 	{
 		cmd := []string{"edge-runtime", "start", "--main-service", "/home/deno/main", "-p", "8081"}
-		if viper.GetBool("DEBUG") {
+		if utils.DebugEnabled() {
 			cmd = append(cmd, "--verbose")
 		}
 		cmdString = strings.Join(cmd, " ")
@@ -148,6 +150,11 @@ func ServeFunctions(ctx context.Context, envFilePath string, noVerifyJWT *bool,
 ` + mainFuncEmbed + `
 EOF
 `}
+	portBindings := nat.PortMap{}
+	if utils.Config.EdgeRuntime.Port != 0 {
+		hostPort := strconv.FormatUint(uint64(utils.Config.EdgeRuntime.Port), 10)
+		portBindings["8081/tcp"] = []nat.PortBinding{{HostPort: hostPort}}
+	}
 	_, err = utils.DockerStart(
 		ctx,
 		container.Config{
@@ -158,8 +165,9 @@ EOF
 			// No tcp health check because edge runtime logs them as client connection error
 		},
 		start.WithSyslogConfig(container.HostConfig{
-			Binds:      binds,
-			ExtraHosts: []string{"host.docker.internal:host-gateway"},
+			Binds:        binds,
+			PortBindings: portBindings,
+			ExtraHosts:   []string{"host.docker.internal:host-gateway"},
 		}),
 		utils.EdgeRuntimeId,
 	)
@@ -191,8 +199,10 @@ func parseEnvFile(envFilePath string, fsys afero.Fs) ([]string, error) {
 
 func populatePerFunctionConfigs(binds []string, importMapPath string, noVerifyJWT *bool, fsys afero.Fs) ([]string, string, error) {
 	type functionConfig struct {
-		ImportMapPath string `json:"importMapPath"`
-		VerifyJWT     bool   `json:"verifyJWT"`
+		ImportMapPath   string `json:"importMapPath"`
+		VerifyJWT       bool   `json:"verifyJWT"`
+		MemoryLimitMb   int64  `json:"memoryLimitMb,omitempty"`
+		WorkerTimeoutMs uint   `json:"workerTimeoutMs,omitempty"`
 	}
 
 	functionsConfig := map[string]functionConfig{}
@@ -234,14 +244,20 @@ func populatePerFunctionConfigs(binds []string, importMapPath string, noVerifyJW
 		verifyJWT := true
 		if noVerifyJWT != nil {
 			verifyJWT = !*noVerifyJWT
-		} else if functionConfig, ok := utils.Config.Functions[functionName]; ok && functionConfig.VerifyJWT != nil {
-			verifyJWT = *functionConfig.VerifyJWT
+		} else if functionConfig, ok := utils.Config.Functions[functionName]; ok {
+			verifyJWT = functionConfig.ShouldVerifyJWT()
 		}
 
-		functionsConfig[functionName] = functionConfig{
+		// Unset limits fall back to the edge runtime defaults in main.ts
+		config := functionConfig{
 			ImportMapPath: dockerImportMapPath,
 			VerifyJWT:     verifyJWT,
 		}
+		if fc, ok := utils.Config.Functions[functionName]; ok {
+			config.MemoryLimitMb = int64(fc.Memory) / units.MiB
+			config.WorkerTimeoutMs = uint(fc.Timeout)
+		}
+		functionsConfig[functionName] = config
 	}
 
 	functionsConfigBytes, err := json.Marshal(functionsConfig)
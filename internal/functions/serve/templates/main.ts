@@ -14,6 +14,8 @@ const FUNCTIONS_CONFIG_STRING = Deno.env.get(
 interface FunctionConfig {
   importMapPath: string;
   verifyJWT: boolean;
+  memoryLimitMb?: number;
+  workerTimeoutMs?: number;
 }
 
 const functionsConfig: Record<string, FunctionConfig> = (() => {
@@ -99,8 +101,9 @@ serve(async (req: Request) => {
   const servicePath = `${FUNCTIONS_PATH}/${functionName}`;
   console.error(`serving the request with ${servicePath}`);
 
-  const memoryLimitMb = 150;
-  const workerTimeoutMs = 5 * 60 * 1000;
+  const memoryLimitMb = functionsConfig[functionName].memoryLimitMb ?? 150;
+  const workerTimeoutMs = functionsConfig[functionName].workerTimeoutMs ??
+    5 * 60 * 1000;
   const noModuleCache = false;
   const envVarsObj = Deno.env.toObject();
   const envVars = Object.entries(envVarsObj)
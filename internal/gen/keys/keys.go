@@ -56,6 +56,18 @@ func Run(ctx context.Context, projectRef, format string, names CustomName, fsys
 	})
 }
 
+// Prints the api keys of the local stack, derived from the configured jwt secret.
+func RunLocal(format string, names CustomName, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	return utils.EncodeOutput(format, os.Stdout, map[string]string{
+		names.JWTSecret:      utils.Config.Auth.JwtSecret,
+		names.AnonKey:        utils.Config.Auth.AnonKey,
+		names.ServiceRoleKey: utils.Config.Auth.ServiceRoleKey,
+	})
+}
+
 func GenerateSecrets(ctx context.Context, projectRef, branch string, fsys afero.Fs) error {
 	// Load JWT secret from api
 	resp, err := utils.GetSupabase().GetPostgRESTConfigWithResponse(ctx, projectRef)
@@ -0,0 +1,167 @@
+package init
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type migrationCopy struct {
+	Source   string
+	Target   string
+	Contents []byte
+}
+
+type schemaLayout struct {
+	Name string
+	Path string
+	Plan func(fsys afero.Fs, path string) ([]migrationCopy, error)
+}
+
+// Schema artifacts commonly left behind by other migration tools.
+var schemaLayouts = []schemaLayout{
+	{Name: "dbmate migrations", Path: filepath.Join("db", "migrations"), Plan: planDbmate},
+	{Name: "prisma migrations", Path: filepath.Join("prisma", "migrations"), Plan: planPrisma},
+	{Name: "schema file", Path: "schema.sql", Plan: planSchemaFile},
+	{Name: "schema file", Path: filepath.Join("db", "structure.sql"), Plan: planSchemaFile},
+}
+
+func adoptExistingSchema(fsys afero.Fs, stdin *os.File) error {
+	for _, layout := range schemaLayouts {
+		if exists, err := afero.Exists(fsys, layout.Path); err != nil {
+			return err
+		} else if !exists {
+			continue
+		}
+		plan, err := layout.Plan(fsys, layout.Path)
+		if err != nil {
+			return err
+		}
+		if len(plan) == 0 {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Found existing %s in %s.\n", layout.Name, utils.Bold(layout.Path))
+		previewImport(plan, os.Stderr)
+		if !utils.PromptYesNo("Import them to "+utils.Bold(utils.MigrationsDir)+"?", false, stdin) {
+			continue
+		}
+		if err := applyImport(fsys, plan); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "Imported", len(plan), "migration files.")
+		// Only a single layout should be imported
+		return nil
+	}
+	return nil
+}
+
+func previewImport(plan []migrationCopy, w io.Writer) {
+	for _, file := range plan {
+		fmt.Fprintf(w, "  %s => %s\n", file.Source, file.Target)
+	}
+}
+
+func applyImport(fsys afero.Fs, plan []migrationCopy) error {
+	for _, file := range plan {
+		if err := utils.WriteFile(file.Target, file.Contents, fsys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dbmate stores migrations as <timestamp>_<name>.sql with up and down sections.
+func planDbmate(fsys afero.Fs, dir string) ([]migrationCopy, error) {
+	entries, err := afero.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	var plan []migrationCopy
+	for _, entry := range entries {
+		if entry.IsDir() || !utils.MigrateFilePattern.MatchString(entry.Name()) {
+			continue
+		}
+		source := filepath.Join(dir, entry.Name())
+		contents, err := afero.ReadFile(fsys, source)
+		if err != nil {
+			return nil, err
+		}
+		file, err := newMigrationCopy(fsys, source, entry.Name(), extractDbmateUp(contents))
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, file)
+	}
+	return plan, nil
+}
+
+func extractDbmateUp(contents []byte) []byte {
+	up := string(contents)
+	if index := strings.Index(up, "-- migrate:down"); index >= 0 {
+		up = up[:index]
+	}
+	up = strings.Replace(up, "-- migrate:up", "", 1)
+	return []byte(strings.TrimSpace(up) + "\n")
+}
+
+// Prisma stores migrations as <timestamp>_<name>/migration.sql
+func planPrisma(fsys afero.Fs, dir string) ([]migrationCopy, error) {
+	entries, err := afero.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	var plan []migrationCopy
+	for _, entry := range entries {
+		name := entry.Name() + ".sql"
+		if !entry.IsDir() || !utils.MigrateFilePattern.MatchString(name) {
+			continue
+		}
+		source := filepath.Join(dir, entry.Name(), "migration.sql")
+		contents, err := afero.ReadFile(fsys, source)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		file, err := newMigrationCopy(fsys, source, name, contents)
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, file)
+	}
+	sort.Slice(plan, func(i, j int) bool {
+		return plan[i].Target < plan[j].Target
+	})
+	return plan, nil
+}
+
+// A single schema dump is imported as the baseline migration.
+func planSchemaFile(fsys afero.Fs, path string) ([]migrationCopy, error) {
+	contents, err := afero.ReadFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	name := utils.GetCurrentTimestamp() + "_baseline_schema.sql"
+	file, err := newMigrationCopy(fsys, path, name, contents)
+	if err != nil {
+		return nil, err
+	}
+	return []migrationCopy{file}, nil
+}
+
+func newMigrationCopy(fsys afero.Fs, source, name string, contents []byte) (migrationCopy, error) {
+	target := filepath.Join(utils.MigrationsDir, name)
+	if exists, err := afero.Exists(fsys, target); err != nil {
+		return migrationCopy{}, err
+	} else if exists {
+		return migrationCopy{}, errors.New("Migration file already exists: " + utils.Bold(target))
+	}
+	return migrationCopy{Source: source, Target: target, Contents: contents}, nil
+}
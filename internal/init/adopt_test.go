@@ -0,0 +1,110 @@
+package init
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestAdoptDbmate(t *testing.T) {
+	dbmate := `-- migrate:up
+create table users (id int);
+
+-- migrate:down
+drop table users;
+`
+
+	t.Run("imports dbmate migrations with timestamps", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		source := filepath.Join("db", "migrations", "20230101120000_create_users.sql")
+		require.NoError(t, afero.WriteFile(fsys, source, []byte(dbmate), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join("db", "migrations", "README.md"), []byte{}, 0644))
+		// Run test
+		plan, err := planDbmate(fsys, filepath.Join("db", "migrations"))
+		require.NoError(t, err)
+		// Check preview
+		require.Len(t, plan, 1)
+		target := filepath.Join(utils.MigrationsDir, "20230101120000_create_users.sql")
+		assert.Equal(t, source, plan[0].Source)
+		assert.Equal(t, target, plan[0].Target)
+		// Nothing is written before applying
+		exists, err := afero.Exists(fsys, target)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		// Check imported file
+		assert.NoError(t, applyImport(fsys, plan))
+		contents, err := afero.ReadFile(fsys, target)
+		assert.NoError(t, err)
+		assert.Equal(t, "create table users (id int);\n", string(contents))
+		// Source file is unchanged
+		original, err := afero.ReadFile(fsys, source)
+		assert.NoError(t, err)
+		assert.Equal(t, dbmate, string(original))
+	})
+
+	t.Run("throws error on existing migration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		name := "20230101120000_create_users.sql"
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join("db", "migrations", name), []byte(dbmate), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, name), []byte{}, 0644))
+		// Run test
+		_, err := planDbmate(fsys, filepath.Join("db", "migrations"))
+		// Check error
+		assert.ErrorContains(t, err, "Migration file already exists")
+	})
+}
+
+func TestAdoptSchemaFile(t *testing.T) {
+	t.Run("imports schema file as baseline", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		schema := []byte("create schema app;\n")
+		require.NoError(t, afero.WriteFile(fsys, "schema.sql", schema, 0644))
+		// Run test
+		plan, err := planSchemaFile(fsys, "schema.sql")
+		require.NoError(t, err)
+		assert.NoError(t, applyImport(fsys, plan))
+		// Check imported file
+		require.Len(t, plan, 1)
+		assert.Regexp(t, `[0-9]{14}_baseline_schema\.sql$`, plan[0].Target)
+		contents, err := afero.ReadFile(fsys, plan[0].Target)
+		assert.NoError(t, err)
+		assert.Equal(t, schema, contents)
+	})
+
+	t.Run("skips import when not confirmed", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join("db", "structure.sql"), []byte("select 1;"), 0644))
+		// Run test
+		assert.NoError(t, adoptExistingSchema(fsys, os.Stdin))
+		// Check nothing is imported
+		exists, err := afero.DirExists(fsys, utils.MigrationsDir)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestAdoptPrisma(t *testing.T) {
+	t.Run("imports prisma migrations in order", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join("prisma", "migrations", "20230102000000_second", "migration.sql"), []byte("select 2;"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join("prisma", "migrations", "20230101000000_init", "migration.sql"), []byte("select 1;"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join("prisma", "migrations", "migration_lock.toml"), []byte{}, 0644))
+		// Run test
+		plan, err := planPrisma(fsys, filepath.Join("prisma", "migrations"))
+		require.NoError(t, err)
+		// Check preview
+		require.Len(t, plan, 2)
+		assert.Equal(t, filepath.Join(utils.MigrationsDir, "20230101000000_init.sql"), plan[0].Target)
+		assert.Equal(t, filepath.Join(utils.MigrationsDir, "20230102000000_second.sql"), plan[1].Target)
+	})
+}
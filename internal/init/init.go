@@ -1,6 +1,7 @@
 package init
 
 import (
+	"context"
 	_ "embed"
 	"errors"
 	"fmt"
@@ -21,42 +22,81 @@ var (
 	//go:embed templates/.code-workspace
 	vscodeWorkspaceConfig string
 
-	errAlreadyInitialized = errors.New("Project already initialized. Remove " + utils.Bold(utils.ConfigPath) + " to reinitialize.")
+	errAlreadyInitialized = errors.New("Project already initialized. Pass " + utils.Aqua("--force") + " or remove " + utils.Bold(utils.ConfigPath) + " to reinitialize.")
 )
 
-func Run(fsys afero.Fs, createVscodeWorkspace *bool) error {
+// Prompts are only shown when interactive is set, otherwise their defaults are used.
+func Run(ctx context.Context, fsys afero.Fs, createVscodeWorkspace *bool, params utils.InitParams, interactive bool) error {
 	// Sanity checks.
 	{
 		if _, err := fsys.Stat(utils.ConfigPath); err == nil {
-			return errAlreadyInitialized
+			if !params.Overwrite && !(interactive && utils.PromptYesNo("Overwrite existing "+utils.Bold(utils.ConfigPath)+"?", false, os.Stdin)) {
+				return errAlreadyInitialized
+			}
+			params.Overwrite = true
 		} else if !errors.Is(err, os.ErrNotExist) {
 			return err
 		}
 	}
 
 	// 1. Write `config.toml`.
-	if err := utils.WriteConfig(fsys, false); err != nil {
+	if len(params.FromRemote) > 0 {
+		if err := utils.InitRemoteConfig(ctx, params.FromRemote, params, fsys); err != nil {
+			return err
+		}
+	} else {
+		if interactive {
+			if err := promptInitParams(&params, os.Stdin, os.Stderr); err != nil {
+				return err
+			}
+			if err := validateInitParams(params, fsys); err != nil {
+				return err
+			}
+		}
+		if err := utils.InitConfig(params, fsys); err != nil {
+			return err
+		}
+	}
+
+	// 2. Create `seed.sql`, keeping any existing seed data on reinitialise.
+	if _, err := fsys.Stat(utils.SeedDataPath); errors.Is(err, os.ErrNotExist) {
+		if _, err := fsys.Create(utils.SeedDataPath); err != nil {
+			return err
+		}
+	} else if err != nil {
 		return err
 	}
 
-	// 2. Create `seed.sql`.
-	if _, err := fsys.Create(utils.SeedDataPath); err != nil {
+	// 3. Import schema from other migration tools.
+	if err := adoptExistingSchema(fsys, os.Stdin); err != nil {
 		return err
 	}
 
-	// 3. Append to `.gitignore`.
+	// 4. Append to `.gitignore`.
 	if utils.IsGitRepo() {
 		if err := updateGitIgnore(utils.GitIgnorePath, fsys); err != nil {
 			return err
 		}
 	}
 
-	// 4. Generate VS Code workspace settings.
+	// 5. Generate editor config for the local project.
+	if len(params.IDEs) > 0 {
+		if err := utils.LoadConfigFS(fsys); err != nil {
+			return err
+		}
+		for _, ide := range params.IDEs {
+			if err := utils.GenerateIDEConfig(ide, utils.Config, fsys); err != nil {
+				return err
+			}
+		}
+	}
+
+	// 6. Generate VS Code workspace settings.
 	if createVscodeWorkspace != nil {
 		if *createVscodeWorkspace {
 			return writeVscodeConfig(fsys)
 		}
-	} else {
+	} else if interactive {
 		if isVscode := utils.PromptYesNo("Generate VS Code workspace settings?", false, os.Stdin); isVscode {
 			return writeVscodeConfig(fsys)
 		}
@@ -1,15 +1,22 @@
 package init
 
 import (
+	"context"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/BurntSushi/toml"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
 	"github.com/supabase/cli/internal/testing/fstest"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestInitCommand(t *testing.T) {
@@ -20,7 +27,7 @@ func TestInitCommand(t *testing.T) {
 		require.NoError(t, err)
 		require.NoError(t, fsys.Mkdir(".git", 0755))
 		// Run test
-		assert.NoError(t, Run(fsys, nil))
+		assert.NoError(t, Run(context.Background(), fsys, nil, utils.InitParams{}, false))
 		// Validate generated config.toml
 		exists, err := afero.Exists(fsys, utils.ConfigPath)
 		assert.NoError(t, err)
@@ -45,14 +52,116 @@ func TestInitCommand(t *testing.T) {
 		_, err := fsys.Create(utils.ConfigPath)
 		require.NoError(t, err)
 		// Run test
-		assert.Error(t, Run(fsys, nil))
+		assert.Error(t, Run(context.Background(), fsys, nil, utils.InitParams{}, false))
+	})
+
+	t.Run("overwrites existing config with force", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := &afero.MemMapFs{}
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
+		require.NoError(t, afero.WriteFile(fsys, utils.SeedDataPath, []byte("select 1;"), 0644))
+		// Run test
+		assert.NoError(t, Run(context.Background(), fsys, nil, utils.InitParams{Overwrite: true}, false))
+		// Validate single template instance
+		contents, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(string(contents), "[api]\n"))
+		_, err = toml.Decode(string(contents), &struct{}{})
+		assert.NoError(t, err)
+		// Validate seed data is kept
+		seed, err := afero.ReadFile(fsys, utils.SeedDataPath)
+		assert.NoError(t, err)
+		assert.Equal(t, "select 1;", string(seed))
+	})
+
+	t.Run("creates config from remote project", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup in-memory fs
+		fsys := &afero.MemMapFs{}
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects").
+			Reply(http.StatusOK).
+			JSON([]api.ProjectResponse{{
+				Id:       project,
+				Database: &api.DatabaseResponse{Version: "14.1.0.89"},
+			}})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/postgrest").
+			Reply(http.StatusOK).
+			JSON(api.PostgrestConfigWithJWTSecretResponse{
+				DbSchema:          "public, private",
+				DbExtraSearchPath: "public,extensions",
+				MaxRows:           500,
+			})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/auth").
+			Reply(http.StatusOK).
+			JSON(map[string]interface{}{
+				"site_url":                  "https://example.com",
+				"uri_allow_list":            "https://example.com/callback,https://preview.example.com",
+				"external_github_enabled":   true,
+				"external_github_client_id": "github-client",
+				"external_github_secret":    "github-secret",
+				"external_google_enabled":   false,
+			})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/config/storage").
+			Reply(http.StatusOK).
+			JSON(api.StorageConfigResponse{FileSizeLimit: 50 << 20})
+		// Run test
+		assert.NoError(t, Run(context.Background(), fsys, nil, utils.InitParams{FromRemote: project}, false))
+		// Validate generated config.toml
+		contents, err := afero.ReadFile(fsys, utils.ConfigPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), `project_id = "`+project+`"`)
+		assert.Contains(t, string(contents), "major_version = 14")
+		assert.Contains(t, string(contents), `schemas = ["public", "private"]`)
+		assert.Contains(t, string(contents), "max_rows = 500")
+		assert.Contains(t, string(contents), `secret = "env(SUPABASE_AUTH_EXTERNAL_APPLE_SECRET)"`)
+		assert.Contains(t, string(contents), `site_url = "https://example.com"`)
+		assert.Contains(t, string(contents), `secret = "env(SUPABASE_AUTH_EXTERNAL_GITHUB_SECRET)"`)
+		assert.NotContains(t, string(contents), "github-secret")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		// Validate generated .env.example
+		example, err := afero.ReadFile(fsys, utils.EnvExamplePath)
+		assert.NoError(t, err)
+		assert.Contains(t, string(example), "\nSUPABASE_AUTH_EXTERNAL_APPLE_SECRET=\n")
+		assert.Contains(t, string(example), "\nSUPABASE_AUTH_EXTERNAL_GITHUB_SECRET=\n")
+		// Check that config is valid
+		t.Setenv("SUPABASE_AUTH_EXTERNAL_GITHUB_SECRET", "github-secret")
+		assert.NoError(t, utils.LoadConfigFS(fsys))
+		assert.Equal(t, "https://example.com", utils.Config.Auth.SiteUrl)
+		assert.Equal(t, []string{"https://example.com/callback", "https://preview.example.com"}, utils.Config.Auth.AdditionalRedirectUrls)
+		github := utils.Config.Auth.External["github"]
+		assert.True(t, github.Enabled)
+		assert.Equal(t, "github-client", github.ClientId)
+		assert.Equal(t, "github-secret", github.Secret)
+		assert.False(t, utils.Config.Auth.External["google"].Enabled)
+	})
+
+	t.Run("throws error on invalid remote project ref", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := &afero.MemMapFs{}
+		// Run test
+		err := Run(context.Background(), fsys, nil, utils.InitParams{FromRemote: "invalid"}, false)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrInvalidRef)
+		exists, err := afero.Exists(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
 	})
 
 	t.Run("throws error on permission denied", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := &fstest.StatErrorFs{DenyPath: utils.ConfigPath}
 		// Run test
-		err := Run(fsys, nil)
+		err := Run(context.Background(), fsys, nil, utils.InitParams{}, false)
 		// Check error
 		assert.ErrorIs(t, err, os.ErrPermission)
 	})
@@ -61,14 +170,14 @@ func TestInitCommand(t *testing.T) {
 		// Setup read-only fs
 		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
 		// Run test
-		assert.Error(t, Run(fsys, nil))
+		assert.Error(t, Run(context.Background(), fsys, nil, utils.InitParams{}, false))
 	})
 
 	t.Run("throws error on seed failure", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := &fstest.CreateErrorFs{DenyPath: utils.SeedDataPath}
 		// Run test
-		err := Run(fsys, nil)
+		err := Run(context.Background(), fsys, nil, utils.InitParams{}, false)
 		// Check error
 		assert.ErrorIs(t, err, os.ErrPermission)
 	})
@@ -79,20 +188,38 @@ func TestInitCommand(t *testing.T) {
 		cwd, err := os.Getwd()
 		require.NoError(t, err)
 		// Run test
-		assert.NoError(t, Run(fsys, boolPointer(true)))
+		assert.NoError(t, Run(context.Background(), fsys, boolPointer(true), utils.InitParams{}, false))
 		// Validate generated vscode workspace
 		exists, err := afero.Exists(fsys, filepath.Join(cwd, "init.code-workspace"))
 		assert.NoError(t, err)
 		assert.True(t, exists)
 	})
 
+	t.Run("creates ide config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := &afero.MemMapFs{}
+		// Run test
+		assert.NoError(t, Run(context.Background(), fsys, nil, utils.InitParams{
+			ProjectId: "test",
+			DbPort:    64322,
+			IDEs:      []string{utils.IDEVscode, utils.IDEIntellij},
+		}, false))
+		// Validate generated ide config
+		exists, err := afero.Exists(fsys, utils.VscodeSettingsPath)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		contents, err := afero.ReadFile(fsys, utils.IntellijDataSources)
+		assert.NoError(t, err)
+		assert.Contains(t, string(contents), "localhost:64322")
+	})
+
 	t.Run("does not create vscode workspace file", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := &afero.MemMapFs{}
 		cwd, err := os.Getwd()
 		require.NoError(t, err)
 		// Run test
-		assert.NoError(t, Run(fsys, boolPointer(false)))
+		assert.NoError(t, Run(context.Background(), fsys, boolPointer(false), utils.InitParams{}, false))
 		// Validate vscode workspace isn't generated
 		exists, err := afero.Exists(fsys, filepath.Join(cwd, "init.code-workspace"))
 		assert.NoError(t, err)
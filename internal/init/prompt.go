@@ -0,0 +1,129 @@
+package init
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	// Services are prompted in this order
+	serviceLabels = []struct {
+		name  string
+		label string
+	}{
+		{"studio", "Supabase Studio"},
+		{"inbucket", "Inbucket email testing server"},
+		{"analytics", "Logflare analytics"},
+	}
+)
+
+// Prompts for ports, db version and optional services, keeping the current values on empty input.
+func promptInitParams(params *utils.InitParams, stdin io.Reader, w io.Writer) error {
+	r := bufio.NewReader(stdin)
+	defaults := params.WithDefaults()
+	var err error
+	if params.ApiPort, err = promptPort(r, w, "API port", defaults.ApiPort); err != nil {
+		return err
+	}
+	if params.DbPort, err = promptPort(r, w, "Database port", defaults.DbPort); err != nil {
+		return err
+	}
+	if params.DbMajorVersion, err = promptUint(r, w, "Postgres major version", defaults.DbMajorVersion, func(value uint) error {
+		if !utils.SliceContains(utils.SupportedMajorVersions, value) {
+			return fmt.Errorf("must be one of: %v", utils.SupportedMajorVersions)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	params.Services = map[string]bool{}
+	for _, service := range serviceLabels {
+		enabled, err := promptBool(r, w, "Enable "+service.label+"?", defaults.Services[service.name])
+		if err != nil {
+			return err
+		}
+		params.Services[service.name] = enabled
+	}
+	if params.Services["studio"] {
+		if params.StudioPort, err = promptPort(r, w, "Studio port", defaults.StudioPort); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Renders the config to memory and loads it, so that invalid answers never reach disk.
+func validateInitParams(params utils.InitParams, fsys afero.Fs) error {
+	overlay := afero.NewCopyOnWriteFs(fsys, afero.NewMemMapFs())
+	params.Overwrite = true
+	if err := utils.InitConfig(params, overlay); err != nil {
+		return err
+	}
+	return utils.LoadConfigFS(overlay)
+}
+
+func promptPort(r *bufio.Reader, w io.Writer, label string, def uint) (uint, error) {
+	return promptUint(r, w, label, def, func(value uint) error {
+		if value == 0 || value > 65535 {
+			return fmt.Errorf("must be between 1 and 65535")
+		}
+		return nil
+	})
+}
+
+func promptUint(r *bufio.Reader, w io.Writer, label string, def uint, validate func(uint) error) (uint, error) {
+	for {
+		input, err := promptLine(r, w, fmt.Sprintf("%s [%d]: ", label, def))
+		if len(input) == 0 {
+			return def, err
+		}
+		value, parseErr := strconv.ParseUint(input, 10, 0)
+		if parseErr == nil {
+			parseErr = validate(uint(value))
+		}
+		if parseErr == nil {
+			return uint(value), nil
+		}
+		fmt.Fprintln(w, "Invalid value:", input, parseErr)
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+func promptBool(r *bufio.Reader, w io.Writer, label string, def bool) (bool, error) {
+	choices := "Y/n"
+	if !def {
+		choices = "y/N"
+	}
+	for {
+		input, err := promptLine(r, w, fmt.Sprintf("%s [%s] ", label, choices))
+		switch strings.ToLower(input) {
+		case "":
+			return def, err
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// Returns the trimmed line and any read error other than EOF on the last line.
+func promptLine(r *bufio.Reader, w io.Writer, prompt string) (string, error) {
+	fmt.Fprint(w, prompt)
+	line, err := r.ReadString('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	}
+	return strings.TrimSpace(line), err
+}
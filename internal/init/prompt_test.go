@@ -0,0 +1,76 @@
+package init
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestPromptInitParams(t *testing.T) {
+	t.Run("uses defaults on empty input", func(t *testing.T) {
+		var params utils.InitParams
+		// Run test
+		assert.NoError(t, promptInitParams(&params, strings.NewReader(strings.Repeat("\n", 7)), io.Discard))
+		// Check error
+		assert.Equal(t, uint(54321), params.ApiPort)
+		assert.Equal(t, uint(54322), params.DbPort)
+		assert.Equal(t, uint(54323), params.StudioPort)
+		assert.Equal(t, uint(15), params.DbMajorVersion)
+		assert.Equal(t, utils.InitServices, params.Services)
+	})
+
+	t.Run("accepts custom values", func(t *testing.T) {
+		var params utils.InitParams
+		input := "6000\n6001\n14\nn\nn\ny\n"
+		// Run test
+		assert.NoError(t, promptInitParams(&params, strings.NewReader(input), io.Discard))
+		// Check error
+		assert.Equal(t, uint(6000), params.ApiPort)
+		assert.Equal(t, uint(6001), params.DbPort)
+		assert.Equal(t, uint(0), params.StudioPort)
+		assert.Equal(t, uint(14), params.DbMajorVersion)
+		assert.Equal(t, map[string]bool{"studio": false, "inbucket": false, "analytics": true}, params.Services)
+	})
+
+	t.Run("prompts again on invalid port", func(t *testing.T) {
+		var params utils.InitParams
+		input := "0\n70000\nabc\n6000\n\n\n\n\n\n\n"
+		var out strings.Builder
+		// Run test
+		assert.NoError(t, promptInitParams(&params, strings.NewReader(input), &out))
+		// Check error
+		assert.Equal(t, uint(6000), params.ApiPort)
+		assert.Equal(t, 3, strings.Count(out.String(), "Invalid value"))
+	})
+
+	t.Run("throws error on unsupported major version", func(t *testing.T) {
+		var params utils.InitParams
+		// Run test
+		err := promptInitParams(&params, strings.NewReader("\n\n12\n"), io.Discard)
+		// Check error
+		assert.ErrorIs(t, err, io.EOF)
+	})
+}
+
+func TestValidateInitParams(t *testing.T) {
+	t.Run("does not write to disk", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.NoError(t, validateInitParams(utils.InitParams{ProjectId: "test"}, fsys))
+		// Check error
+		exists, err := afero.Exists(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("throws error on invalid major version", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		params := utils.InitParams{ProjectId: "test", DbMajorVersion: 12}
+		// Run test
+		assert.Error(t, validateInitParams(params, fsys))
+	})
+}
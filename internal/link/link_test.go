@@ -43,7 +43,7 @@ func TestPreRun(t *testing.T) {
 		project := apitest.RandomProjectRef()
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
 		// Run test
 		err := PreRun(project, fsys)
 		// Check error
@@ -23,17 +23,32 @@ func MigrateAndSeed(ctx context.Context, version string, conn *pgx.Conn, fsys af
 	if err := MigrateUp(ctx, conn, migrations, fsys); err != nil {
 		return err
 	}
+	if !utils.Config.Db.Seed.Enabled {
+		return nil
+	}
 	return SeedDatabase(ctx, conn, fsys)
 }
 
 func SeedDatabase(ctx context.Context, conn *pgx.Conn, fsys afero.Fs) error {
-	seed, err := repair.NewMigrationFromFile(utils.SeedDataPath, fsys)
+	if len(utils.Config.Db.Seed.Paths) == 0 {
+		return seedFromFile(ctx, conn, utils.SeedDataPath, fsys)
+	}
+	for _, path := range utils.Config.Db.Seed.SqlPaths {
+		if err := seedFromFile(ctx, conn, path, fsys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func seedFromFile(ctx context.Context, conn *pgx.Conn, path string, fsys afero.Fs) error {
+	seed, err := repair.NewMigrationFromFile(path, fsys)
 	if errors.Is(err, os.ErrNotExist) {
 		return nil
 	} else if err != nil {
 		return err
 	}
-	fmt.Fprintln(os.Stderr, "Seeding data "+utils.Bold(utils.SeedDataPath)+"...")
+	fmt.Fprintln(os.Stderr, "Seeding data "+utils.Bold(path)+"...")
 	// Batch seed commands, safe to use statement cache
 	return seed.ExecBatchWithCache(ctx, conn)
 }
@@ -86,6 +86,45 @@ func TestSeedDatabase(t *testing.T) {
 		assert.NoError(t, SeedDatabase(ctx, mock, fsys))
 	})
 
+	t.Run("seeds from configured paths in order", func(t *testing.T) {
+		utils.Config.Db.Seed.Paths = []string{"supabase/seeds/*.sql"}
+		utils.Config.Db.Seed.SqlPaths = []string{"supabase/seeds/a.sql", "supabase/seeds/b.sql"}
+		defer func() {
+			utils.Config.Db.Seed.Paths = nil
+			utils.Config.Db.Seed.SqlPaths = nil
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "supabase/seeds/a.sql", []byte("INSERT INTO a VALUES (1)"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "supabase/seeds/b.sql", []byte("INSERT INTO b VALUES (2)"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("INSERT INTO a VALUES (1)").
+			Reply("INSERT 0 1").
+			Query("INSERT INTO b VALUES (2)").
+			Reply("INSERT 0 1")
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{Port: 5432}, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		assert.NoError(t, SeedDatabase(ctx, mock, fsys))
+	})
+
+	t.Run("skips seed when disabled", func(t *testing.T) {
+		utils.Config.Db.Seed.Enabled = false
+		defer func() {
+			utils.Config.Db.Seed.Enabled = true
+		}()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.SeedDataPath, []byte("INSERT INTO a VALUES (1)"), 0644))
+		// Run test
+		assert.NoError(t, MigrateAndSeed(context.Background(), "", nil, fsys))
+	})
+
 	t.Run("ignores missing seed", func(t *testing.T) {
 		assert.NoError(t, SeedDatabase(context.Background(), nil, afero.NewMemMapFs()))
 	})
@@ -0,0 +1,258 @@
+package start
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Mount point of the storage volume in the storage container.
+const dockerStoragePath = "/mnt"
+
+// Connection config of the local database container, as seen from other containers.
+func LocalDbConfig() pgconn.Config {
+	return pgconn.Config{
+		Host:     utils.DbId,
+		Port:     5432,
+		User:     "postgres",
+		Password: utils.Config.Db.Password,
+		Database: "postgres",
+	}
+}
+
+// The functions below derive the environment of each service container from the loaded config,
+// so that supabase start and supabase config env render the same variables.
+
+func LogflareEnv(dbConfig pgconn.Config) []string {
+	logLevel := "warn"
+	switch utils.Config.Analytics.LogLevel {
+	case "":
+	case utils.LogLevelWarn:
+		// Elixir deprecated the warn level in favour of warning
+		logLevel = "warning"
+	default:
+		logLevel = string(utils.Config.Analytics.LogLevel)
+	}
+	env := []string{
+		"DB_DATABASE=" + dbConfig.Database,
+		"DB_HOSTNAME=" + dbConfig.Host,
+		fmt.Sprintf("DB_PORT=%d", dbConfig.Port),
+		"DB_SCHEMA=_analytics",
+		"DB_USERNAME=supabase_admin",
+		"DB_PASSWORD=" + dbConfig.Password,
+		"LOGFLARE_MIN_CLUSTER_SIZE=1",
+		"LOGFLARE_SINGLE_TENANT=true",
+		"LOGFLARE_SUPABASE_MODE=true",
+		"LOGFLARE_API_KEY=" + utils.Config.Analytics.ApiKey,
+		"LOGFLARE_LOG_LEVEL=" + logLevel,
+		"LOGFLARE_NODE_HOST=127.0.0.1",
+		"LOGFLARE_FEATURE_FLAG_OVERRIDE='multibackend=true'",
+		"RELEASE_COOKIE=cookie",
+	}
+	switch utils.Config.Analytics.Backend {
+	case utils.LogflareBigQuery:
+		// This is hardcoded in studio frontend
+		env = append(env,
+			"GOOGLE_DATASET_ID_APPEND=_prod",
+			"GOOGLE_PROJECT_ID="+utils.Config.Analytics.GcpProjectId,
+			"GOOGLE_PROJECT_NUMBER="+utils.Config.Analytics.GcpProjectNumber,
+		)
+	case utils.LogflarePostgres:
+		env = append(env,
+			fmt.Sprintf("POSTGRES_BACKEND_URL=postgresql://%s:%s@%s:%d/%s", dbConfig.User, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Database),
+			"POSTGRES_BACKEND_SCHEMA=_analytics",
+		)
+	}
+	return env
+}
+
+func GotrueEnv(dbConfig pgconn.Config) []string {
+	env := []string{
+		fmt.Sprintf("API_EXTERNAL_URL=http://localhost:%v", utils.Config.Api.Port),
+
+		"GOTRUE_API_HOST=0.0.0.0",
+		"GOTRUE_API_PORT=9999",
+
+		"GOTRUE_DB_DRIVER=postgres",
+		fmt.Sprintf("GOTRUE_DB_DATABASE_URL=postgresql://supabase_auth_admin:%s@%s:%d/%s", dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Database),
+
+		"GOTRUE_SITE_URL=" + utils.Config.Auth.SiteUrl,
+		"GOTRUE_URI_ALLOW_LIST=" + strings.Join(utils.Config.Auth.AdditionalRedirectUrls, ","),
+		fmt.Sprintf("GOTRUE_DISABLE_SIGNUP=%v", !utils.Config.Auth.EnableSignup),
+
+		"GOTRUE_JWT_ADMIN_ROLES=service_role",
+		"GOTRUE_JWT_AUD=authenticated",
+		"GOTRUE_JWT_DEFAULT_GROUP_NAME=authenticated",
+		fmt.Sprintf("GOTRUE_JWT_EXP=%v", utils.Config.Auth.JwtExpiry),
+		"GOTRUE_JWT_SECRET=" + utils.Config.Auth.JwtSecret,
+		"GOTRUE_JWT_ALGORITHM=" + string(utils.Config.Auth.JwtAlgorithm),
+		"GOTRUE_JWT_PUBLIC_KEY=" + utils.Config.Auth.JwtPublicKey,
+
+		fmt.Sprintf("GOTRUE_EXTERNAL_EMAIL_ENABLED=%v", utils.Config.Auth.Email.EnableSignup),
+		fmt.Sprintf("GOTRUE_MAILER_SECURE_EMAIL_CHANGE_ENABLED=%v", utils.Config.Auth.Email.DoubleConfirmChanges),
+		fmt.Sprintf("GOTRUE_MAILER_AUTOCONFIRM=%v", !utils.Config.Auth.Email.EnableConfirmations),
+
+		// TODO: To be reverted to `/auth/v1/verify` once
+		// https://github.com/supabase/supabase/issues/16100
+		// is fixed on upstream GoTrue.
+		fmt.Sprintf("GOTRUE_MAILER_URLPATHS_INVITE=http://localhost:%v/auth/v1/verify", utils.Config.Api.Port),
+		fmt.Sprintf("GOTRUE_MAILER_URLPATHS_CONFIRMATION=http://localhost:%v/auth/v1/verify", utils.Config.Api.Port),
+		fmt.Sprintf("GOTRUE_MAILER_URLPATHS_RECOVERY=http://localhost:%v/auth/v1/verify", utils.Config.Api.Port),
+		fmt.Sprintf("GOTRUE_MAILER_URLPATHS_EMAIL_CHANGE=http://localhost:%v/auth/v1/verify", utils.Config.Api.Port),
+		"GOTRUE_RATE_LIMIT_EMAIL_SENT=360000",
+
+		fmt.Sprintf("GOTRUE_SECURITY_REFRESH_TOKEN_ROTATION_ENABLED=%v", utils.Config.Auth.EnableRefreshTokenRotation),
+		fmt.Sprintf("GOTRUE_SECURITY_REFRESH_TOKEN_REUSE_INTERVAL=%v", utils.Config.Auth.RefreshTokenReuseInterval),
+	}
+
+	if utils.Config.Auth.Email.OtpLength > 0 {
+		env = append(env, fmt.Sprintf("GOTRUE_MAILER_OTP_LENGTH=%d", utils.Config.Auth.Email.OtpLength))
+	}
+	if utils.Config.Auth.Email.OtpExpiry > 0 {
+		env = append(env, fmt.Sprintf("GOTRUE_MAILER_OTP_EXP=%d", utils.Config.Auth.Email.OtpExpiry))
+	}
+
+	for id, tmpl := range utils.Config.Auth.Email.Template {
+		if len(tmpl.ContentPath) > 0 {
+			env = append(env, fmt.Sprintf("GOTRUE_MAILER_TEMPLATES_%s=http://%s:%d/email/%s",
+				strings.ToUpper(id),
+				utils.KongId,
+				nginxTemplateServerPort,
+				id+filepath.Ext(tmpl.ContentPath),
+			))
+		}
+		if len(tmpl.Subject) > 0 {
+			env = append(env, fmt.Sprintf("GOTRUE_MAILER_SUBJECTS_%s=%s",
+				strings.ToUpper(id),
+				tmpl.Subject,
+			))
+		}
+	}
+
+	hooks := make([]string, 0, len(utils.Config.Auth.Hook))
+	for name := range utils.Config.Auth.Hook {
+		hooks = append(hooks, name)
+	}
+	// Sorted so that the container env is stable between runs
+	sort.Strings(hooks)
+	for _, name := range hooks {
+		hook := utils.Config.Auth.Hook[name]
+		if !hook.Enabled {
+			continue
+		}
+		env = append(
+			env,
+			fmt.Sprintf("GOTRUE_HOOK_%s_ENABLED=true", strings.ToUpper(name)),
+			fmt.Sprintf("GOTRUE_HOOK_%s_URI=%s", strings.ToUpper(name), hook.URI),
+			fmt.Sprintf("GOTRUE_HOOK_%s_SECRETS=%s", strings.ToUpper(name), hook.Secrets),
+		)
+	}
+
+	authEnv := utils.Config.AuthEnv()
+	names := make([]string, 0, len(authEnv))
+	for name := range authEnv {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env = append(env, name+"="+authEnv[name])
+	}
+	return env
+}
+
+// Vector does not connect to the database, but takes the same argument as other services.
+func VectorEnv(_ pgconn.Config) []string {
+	env := []string{"VECTOR_CONFIG=/etc/vector/vector.yaml"}
+	if level := utils.Config.Analytics.LogLevel; len(level) > 0 {
+		env = append(env, "VECTOR_LOG="+string(level))
+	}
+	return env
+}
+
+func RealtimeEnv(dbConfig pgconn.Config) []string {
+	return []string{
+		fmt.Sprintf("PORT=%d", utils.Config.Realtime.Port),
+		"DB_HOST=" + dbConfig.Host,
+		fmt.Sprintf("DB_PORT=%d", dbConfig.Port),
+		"DB_USER=supabase_admin",
+		"DB_PASSWORD=" + dbConfig.Password,
+		"DB_NAME=" + dbConfig.Database,
+		"DB_AFTER_CONNECT_QUERY=SET search_path TO _realtime",
+		"DB_ENC_KEY=supabaserealtime",
+		"API_JWT_SECRET=" + utils.Config.Auth.JwtSecret,
+		"FLY_ALLOC_ID=abc123",
+		"FLY_APP_NAME=realtime",
+		"SECRET_KEY_BASE=EAx3IQ/wRG1v47ZD4NE4/9RzBI8Jmil3x0yhcW4V2NHBP6c2iPIzwjofi2Ep4HIG",
+		"ERL_AFLAGS=-proto_dist inet_tcp",
+		"ENABLE_TAILSCALE=false",
+		"DNS_NODES=''",
+		"RLIMIT_NOFILE=",
+		"REALTIME_IP_VERSION=" + string(utils.Config.Realtime.IpVersion),
+		fmt.Sprintf("TENANT_MAX_CONCURRENT_USERS=%d", utils.Config.Realtime.MaxConcurrentUsers),
+		fmt.Sprintf("TENANT_MAX_CHANNELS_PER_CLIENT=%d", utils.Config.Realtime.MaxChannelsPerClient),
+	}
+}
+
+// The jwt secret may be a JWKS that includes third-party keys, which requires network access
+// to resolve.
+func PostgrestEnv(dbConfig pgconn.Config, jwtSecret string) []string {
+	dbUri := fmt.Sprintf("postgresql://authenticator:%s@%s:%d/%s", dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Database)
+	// Set on the connection as well, since the server defaults do not apply to a remote db
+	var options []string
+	if timeout := utils.Config.Db.Timeouts.StatementTimeout; timeout > 0 {
+		options = append(options, fmt.Sprintf("-c statement_timeout=%d", timeout))
+	}
+	if timeout := utils.Config.Db.Timeouts.IdleInTransactionSessionTimeout; timeout > 0 {
+		options = append(options, fmt.Sprintf("-c idle_in_transaction_session_timeout=%d", timeout))
+	}
+	if len(options) > 0 {
+		dbUri += "?options=" + url.PathEscape(strings.Join(options, " "))
+	}
+	return []string{
+		"PGRST_DB_URI=" + dbUri,
+		"PGRST_DB_SCHEMAS=" + strings.Join(utils.Config.Api.Schemas, ","),
+		"PGRST_DB_EXTRA_SEARCH_PATH=" + strings.Join(utils.Config.Api.ExtraSearchPath, ","),
+		fmt.Sprintf("PGRST_DB_MAX_ROWS=%d", utils.Config.Api.MaxRows),
+		"PGRST_DB_ANON_ROLE=anon",
+		"PGRST_JWT_SECRET=" + jwtSecret,
+	}
+}
+
+func StorageEnv(dbConfig pgconn.Config) []string {
+	env := []string{
+		"ANON_KEY=" + utils.Config.Auth.AnonKey,
+		"SERVICE_KEY=" + utils.Config.Auth.ServiceRoleKey,
+		"POSTGREST_URL=http://" + utils.RestId + ":3000",
+		"PGRST_JWT_SECRET=" + utils.Config.Auth.JwtSecret,
+		fmt.Sprintf("DATABASE_URL=postgresql://supabase_storage_admin:%s@%s:%d/%s", dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Database),
+		fmt.Sprintf("FILE_SIZE_LIMIT=%v", utils.Config.Storage.FileSizeLimit),
+		"TENANT_ID=stub",
+		fmt.Sprintf("ENABLE_IMAGE_TRANSFORMATION=%t", utils.Config.Storage.ImageTransformation.Enabled),
+		"IMGPROXY_URL=http://" + utils.ImgProxyId + ":5001",
+	}
+	if utils.Config.Storage.Backend == utils.StorageBackendS3 {
+		env = append(env,
+			"STORAGE_BACKEND=s3",
+			"REGION="+utils.Config.Storage.S3.Region,
+			"GLOBAL_S3_BUCKET="+utils.Config.Storage.S3.Bucket,
+			"GLOBAL_S3_ENDPOINT="+utils.Config.Storage.S3.Endpoint,
+			"GLOBAL_S3_FORCE_PATH_STYLE=true",
+			"AWS_ACCESS_KEY_ID="+utils.Config.Storage.S3.AccessKey,
+			"AWS_SECRET_ACCESS_KEY="+utils.Config.Storage.S3.SecretKey,
+		)
+	} else {
+		env = append(env,
+			"STORAGE_BACKEND=file",
+			"FILE_STORAGE_BACKEND_PATH="+dockerStoragePath,
+			// TODO: https://github.com/supabase/storage-api/issues/55
+			"REGION=stub",
+			"GLOBAL_S3_BUCKET=stub",
+		)
+	}
+	return env
+}
@@ -0,0 +1,75 @@
+package start
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/supabase/cli/internal/utils"
+)
+
+type jwks struct {
+	Keys []json.RawMessage `json:"keys"`
+}
+
+// Returns the jwt verification key for PostgREST. When a third-party auth provider is enabled,
+// this is a JWK set containing both the local signing key and the provider's public keys.
+func resolveJwtSecret(ctx context.Context) (string, error) {
+	url := utils.Config.Auth.ThirdParty.JwksURL()
+	if len(url) == 0 {
+		return utils.Config.Auth.JwtVerificationKey(), nil
+	}
+	local, err := localJwk()
+	if err != nil {
+		return "", err
+	}
+	remote, err := fetchJwks(ctx, url)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch third-party JWKS: %w", err)
+	}
+	result := jwks{Keys: append([]json.RawMessage{local}, remote.Keys...)}
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func localJwk() (json.RawMessage, error) {
+	if utils.Config.Auth.IsAsymmetricJwt() {
+		// Public keys are already configured as JWK
+		return json.RawMessage(utils.Config.Auth.JwtPublicKey), nil
+	}
+	return json.Marshal(map[string]string{
+		"kty": "oct",
+		"k":   base64.RawURLEncoding.EncodeToString([]byte(utils.Config.Auth.JwtSecret)),
+	})
+}
+
+func fetchJwks(ctx context.Context, url string) (jwks, error) {
+	var result jwks
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return result, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil || len(body) == 0 {
+			body = []byte(fmt.Sprintf("status %d", resp.StatusCode))
+		}
+		return result, errors.New(string(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package start
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/utils"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestResolveJwtSecret(t *testing.T) {
+	t.Run("uses jwt secret by default", func(t *testing.T) {
+		secret, err := resolveJwtSecret(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, utils.Config.Auth.JwtSecret, secret)
+	})
+
+	t.Run("merges third-party keys", func(t *testing.T) {
+		utils.Config.Auth.ThirdParty.AwsCognito.Enabled = true
+		utils.Config.Auth.ThirdParty.AwsCognito.UserPoolId = "test-pool"
+		utils.Config.Auth.ThirdParty.AwsCognito.UserPoolRegion = "us-east-1"
+		defer func() { utils.Config.Auth.ThirdParty.AwsCognito.Enabled = false }()
+		// Setup mock server
+		defer gock.OffAll()
+		gock.New("https://cognito-idp.us-east-1.amazonaws.com").
+			Get("/test-pool/.well-known/jwks.json").
+			Reply(http.StatusOK).
+			JSON(map[string]interface{}{"keys": []map[string]string{{"kty": "RSA", "kid": "test"}}})
+		// Run test
+		secret, err := resolveJwtSecret(context.Background())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, gock.Pending())
+		assert.JSONEq(t, `{"keys": [
+			{"kty": "oct", "k": "c3VwZXItc2VjcmV0LWp3dC10b2tlbi13aXRoLWF0LWxlYXN0LTMyLWNoYXJhY3RlcnMtbG9uZw"},
+			{"kty": "RSA", "kid": "test"}
+		]}`, secret)
+	})
+
+	t.Run("throws error on network failure", func(t *testing.T) {
+		utils.Config.Auth.ThirdParty.Firebase.Enabled = true
+		utils.Config.Auth.ThirdParty.Firebase.ProjectId = "test"
+		defer func() { utils.Config.Auth.ThirdParty.Firebase.Enabled = false }()
+		// Setup mock server
+		defer gock.OffAll()
+		gock.New("https://www.googleapis.com").
+			Get("/service_accounts/v1/jwk/securetoken@system.gserviceaccount.com").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := resolveJwtSecret(context.Background())
+		// Check error
+		assert.ErrorContains(t, err, "failed to fetch third-party JWKS: status 503")
+		assert.Empty(t, gock.Pending())
+	})
+}
@@ -0,0 +1,104 @@
+package start
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Service images to recreate when a top level config section changes. Sections not listed here,
+// such as db and analytics, are shared by all services and require a full restart.
+var sectionImages = map[string][]string{
+	"api":               {utils.KongImage, utils.PostgrestImage},
+	"auth":              {utils.GotrueImage},
+	"realtime":          {utils.RealtimeImage, utils.KongImage},
+	"storage":           {utils.StorageImage, utils.ImageProxyImage},
+	"studio":            {utils.StudioImage, utils.PgmetaImage},
+	"inbucket":          {utils.InbucketImage},
+	"functions":         {utils.EdgeRuntimeImage},
+	"functions_default": {utils.EdgeRuntimeImage},
+	"edge_runtime":      {utils.EdgeRuntimeImage},
+	// Only affects the CLI itself
+	"logging": {},
+}
+
+func containerIds() map[string]string {
+	return map[string]string{
+		utils.KongImage:        utils.KongId,
+		utils.PostgrestImage:   utils.RestId,
+		utils.GotrueImage:      utils.GotrueId,
+		utils.RealtimeImage:    utils.RealtimeId,
+		utils.StorageImage:     utils.StorageId,
+		utils.ImageProxyImage:  utils.ImgProxyId,
+		utils.StudioImage:      utils.StudioId,
+		utils.PgmetaImage:      utils.PgmetaId,
+		utils.InbucketImage:    utils.InbucketId,
+		utils.EdgeRuntimeImage: utils.EdgeRuntimeId,
+	}
+}
+
+// Recreates the containers of services whose config sections have changed, leaving all other
+// containers running. Docker does not support updating the env of an existing container.
+func Reconfigure(ctx context.Context, fsys afero.Fs, sections []string) error {
+	recreate := map[string]bool{}
+	var restart []string
+	for _, name := range sections {
+		images, ok := sectionImages[name]
+		if !ok {
+			restart = append(restart, name)
+			continue
+		}
+		for _, image := range images {
+			recreate[image] = true
+		}
+	}
+	sort.Strings(restart)
+	for _, name := range restart {
+		utils.Warnf("Changes to [%s] require restarting with %s\n", name, utils.Aqua("supabase stop && supabase start"))
+	}
+	if len(recreate) == 0 {
+		return nil
+	}
+	var excluded []string
+	for _, image := range utils.ServiceImages {
+		if !recreate[image] {
+			excluded = append(excluded, utils.ShortContainerImageName(image))
+		}
+	}
+	ids := containerIds()
+	var names []string
+	for image := range recreate {
+		utils.DockerRemove(ids[image])
+		names = append(names, utils.ShortContainerImageName(image))
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, "Recreating containers:", names)
+	if err := utils.RunProgram(ctx, func(p utils.Program, ctx context.Context) error {
+		dbConfig := pgconn.Config{
+			Host:     utils.DbId,
+			Port:     5432,
+			User:     "postgres",
+			Password: utils.Config.Db.Password,
+			Database: "postgres",
+		}
+		return startContainers(p, ctx, fsys, excluded, dbConfig, false)
+	}); err != nil {
+		return err
+	}
+	return saveAppliedConfig(fsys)
+}
+
+// Keeps a copy of the config that running containers were started with, so that later reloads
+// only recreate services whose config has changed.
+func saveAppliedConfig(fsys afero.Fs) error {
+	contents, err := afero.ReadFile(fsys, utils.ConfigPath)
+	if err != nil {
+		return err
+	}
+	return utils.WriteFile(utils.AppliedConfigPath, contents, fsys)
+}
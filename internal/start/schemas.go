@@ -0,0 +1,78 @@
+package start
+
+import (
+	"context"
+	"path"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Expands glob patterns, such as app_*, against the schemas in the database. Plain names are kept
+// as is, while matched schemas are inserted in place of their pattern in alphabetical order.
+func ResolveSchemaGlobs(ctx context.Context, patterns []string, conn *pgx.Conn) ([]string, error) {
+	if !hasSchemaGlob(patterns) {
+		return patterns, nil
+	}
+	existing, err := reset.ListSchemas(ctx, conn, "information_schema", "pg_*")
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, pattern := range patterns {
+		if !utils.IsSchemaGlob(pattern) {
+			if !utils.SliceContains(result, pattern) {
+				result = append(result, pattern)
+			}
+			continue
+		}
+		var matched bool
+		for _, name := range existing {
+			// Patterns are validated when loading config
+			if ok, _ := path.Match(pattern, name); ok {
+				if !utils.SliceContains(result, name) {
+					result = append(result, name)
+				}
+				matched = true
+			}
+		}
+		if !matched {
+			utils.Warnf("No schemas matched pattern: %s", pattern)
+		}
+	}
+	return result, nil
+}
+
+// Resolves glob patterns in api config before they are passed to PostgREST.
+func resolveApiSchemas(ctx context.Context, dbConfig pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	if !hasSchemaGlob(utils.Config.Api.Schemas) && !hasSchemaGlob(utils.Config.Api.ExtraSearchPath) {
+		return nil
+	}
+	var conn *pgx.Conn
+	var err error
+	if dbConfig.Host == utils.DbId {
+		conn, err = utils.ConnectLocalPostgres(ctx, pgconn.Config{}, options...)
+	} else {
+		conn, err = utils.ConnectRemotePostgres(ctx, dbConfig, options...)
+	}
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if utils.Config.Api.Schemas, err = ResolveSchemaGlobs(ctx, utils.Config.Api.Schemas, conn); err != nil {
+		return err
+	}
+	utils.Config.Api.ExtraSearchPath, err = ResolveSchemaGlobs(ctx, utils.Config.Api.ExtraSearchPath, conn)
+	return err
+}
+
+func hasSchemaGlob(names []string) bool {
+	for _, name := range names {
+		if utils.IsSchemaGlob(name) {
+			return true
+		}
+	}
+	return false
+}
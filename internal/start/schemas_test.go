@@ -0,0 +1,96 @@
+package start
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/testing/pgtest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+var listSchemasQuery = strings.ReplaceAll(reset.LIST_SCHEMAS, "$1", `'{"information\\_schema","pg\\_%"}'`)
+
+func TestResolveSchemaGlobs(t *testing.T) {
+	t.Run("expands glob patterns in place", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listSchemasQuery).
+			Reply("SELECT 4", []interface{}{"app_billing"}, []interface{}{"app_users"}, []interface{}{"public"}, []interface{}{"storage"})
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectRemotePostgres(ctx, dbConfig, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		schemas, err := ResolveSchemaGlobs(ctx, []string{"public", "app_*", "storage", "app_users"}, mock)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"public", "app_billing", "app_users", "storage"}, schemas)
+	})
+
+	t.Run("drops unmatched patterns", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listSchemasQuery).
+			Reply("SELECT 1", []interface{}{"public"})
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectRemotePostgres(ctx, dbConfig, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		schemas, err := ResolveSchemaGlobs(ctx, []string{"public", "app_?"}, mock)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"public"}, schemas)
+	})
+
+	t.Run("skips query without patterns", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectRemotePostgres(ctx, dbConfig, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		schemas, err := ResolveSchemaGlobs(ctx, []string{"public", "storage"}, mock)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"public", "storage"}, schemas)
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listSchemasQuery).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for relation information_schema")
+		// Connect to mock
+		ctx := context.Background()
+		mock, err := utils.ConnectRemotePostgres(ctx, dbConfig, conn.Intercept)
+		require.NoError(t, err)
+		defer mock.Close(ctx)
+		// Run test
+		_, err = ResolveSchemaGlobs(ctx, []string{"app_*"}, mock)
+		// Check error
+		assert.ErrorContains(t, err, "permission denied")
+	})
+}
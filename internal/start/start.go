@@ -4,14 +4,12 @@ import (
 	"bytes"
 	"context"
 	_ "embed"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path"
 	"path/filepath"
 	"strconv"
-	"strings"
 	"text/template"
 	"time"
 
@@ -65,13 +63,7 @@ func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignore
 				Database: "postgres",
 			}
 		} else {
-			dbConfig = pgconn.Config{
-				Host:     utils.DbId,
-				Port:     5432,
-				User:     "postgres",
-				Password: utils.Config.Db.Password,
-				Database: "postgres",
-			}
+			dbConfig = LocalDbConfig()
 		}
 		return run(p, ctx, fsys, excludedContainers, dbConfig)
 	}); err != nil {
@@ -83,6 +75,9 @@ func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignore
 		}
 	}
 
+	if err := saveAppliedConfig(fsys); err != nil {
+		return err
+	}
 	fmt.Fprintf(os.Stderr, "Started %s local development setup.\n\n", utils.Aqua("supabase"))
 	status.PrettyPrint(os.Stdout, excludedContainers...)
 	return nil
@@ -97,6 +92,25 @@ type kongConfig struct {
 	EdgeRuntimeId string
 	LogflareId    string
 	ApiPort       uint
+	RealtimePort  uint
+	RateLimiting  *kongRateLimiting
+}
+
+type kongRateLimiting struct {
+	Second     float64
+	LimitBy    string
+	HeaderName string
+}
+
+// Maps api.rate_limiting to the config of Kong's rate-limiting plugin. Local Kong has no
+// consumers, so users are identified by their Authorization header instead.
+func newKongRateLimiting(key utils.RateLimitKey, rps float64) *kongRateLimiting {
+	result := kongRateLimiting{Second: rps, LimitBy: string(key)}
+	if key == utils.RateLimitByUser {
+		result.LimitBy = "header"
+		result.HeaderName = "Authorization"
+	}
+	return &result
 }
 
 var (
@@ -130,6 +144,12 @@ var (
 )
 
 func run(p utils.Program, ctx context.Context, fsys afero.Fs, excludedContainers []string, dbConfig pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	return startContainers(p, ctx, fsys, excludedContainers, dbConfig, dbConfig.Host == utils.DbId, options...)
+}
+
+// Starts all service containers that are not excluded. The local database is only started when
+// startDb is set, which config reload clears to keep the running database untouched.
+func startContainers(p utils.Program, ctx context.Context, fsys afero.Fs, excludedContainers []string, dbConfig pgconn.Config, startDb bool, options ...func(*pgx.ConnConfig)) error {
 	excluded := make(map[string]bool)
 	for _, name := range excludedContainers {
 		excluded[name] = true
@@ -156,9 +176,7 @@ func run(p utils.Program, ctx context.Context, fsys afero.Fs, excludedContainers
 			ctx,
 			container.Config{
 				Image: utils.VectorImage,
-				Env: []string{
-					"VECTOR_CONFIG=/etc/vector/vector.yaml",
-				},
+				Env:   VectorEnv(dbConfig),
 				Entrypoint: []string{"sh", "-c", `cat <<'EOF' > /etc/vector/vector.yaml && vector
 ` + vectorConfigBuf.String() + `
 EOF
@@ -191,7 +209,7 @@ EOF
 
 	// Start Postgres.
 	w := utils.StatusWriter{Program: p}
-	if dbConfig.Host == utils.DbId {
+	if startDb {
 		if err := start.StartDatabase(ctx, fsys, w, options...); err != nil {
 			return err
 		}
@@ -200,43 +218,16 @@ EOF
 	var started []string
 	// Start Logflare
 	if utils.Config.Analytics.Enabled && !isContainerExcluded(utils.LogflareImage, excluded) {
-		env := []string{
-			"DB_DATABASE=" + dbConfig.Database,
-			"DB_HOSTNAME=" + dbConfig.Host,
-			fmt.Sprintf("DB_PORT=%d", dbConfig.Port),
-			"DB_SCHEMA=_analytics",
-			"DB_USERNAME=supabase_admin",
-			"DB_PASSWORD=" + dbConfig.Password,
-			"LOGFLARE_MIN_CLUSTER_SIZE=1",
-			"LOGFLARE_SINGLE_TENANT=true",
-			"LOGFLARE_SUPABASE_MODE=true",
-			"LOGFLARE_API_KEY=" + utils.Config.Analytics.ApiKey,
-			"LOGFLARE_LOG_LEVEL=warn",
-			"LOGFLARE_NODE_HOST=127.0.0.1",
-			"LOGFLARE_FEATURE_FLAG_OVERRIDE='multibackend=true'",
-			"RELEASE_COOKIE=cookie",
-		}
+		env := LogflareEnv(dbConfig)
 		bind := []string{}
 
-		switch utils.Config.Analytics.Backend {
-		case utils.LogflareBigQuery:
+		if utils.Config.Analytics.Backend == utils.LogflareBigQuery {
 			workdir, err := os.Getwd()
 			if err != nil {
 				return err
 			}
 			hostJwtPath := filepath.Join(workdir, utils.Config.Analytics.GcpJwtPath)
 			bind = append(bind, hostJwtPath+":/opt/app/rel/logflare/bin/gcloud.json")
-			// This is hardcoded in studio frontend
-			env = append(env,
-				"GOOGLE_DATASET_ID_APPEND=_prod",
-				"GOOGLE_PROJECT_ID="+utils.Config.Analytics.GcpProjectId,
-				"GOOGLE_PROJECT_NUMBER="+utils.Config.Analytics.GcpProjectNumber,
-			)
-		case utils.LogflarePostgres:
-			env = append(env,
-				fmt.Sprintf("POSTGRES_BACKEND_URL=postgresql://%s:%s@%s:%d/%s", dbConfig.User, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Database),
-				"POSTGRES_BACKEND_SCHEMA=_analytics",
-			)
 		}
 
 		if _, err := utils.DockerStart(
@@ -278,6 +269,12 @@ EOF
 	// Start Kong.
 	p.Send(utils.StatusMsg("Starting containers..."))
 	if !isContainerExcluded(utils.KongImage, excluded) {
+		kongPlugins := "request-transformer,cors"
+		var rateLimiting *kongRateLimiting
+		if utils.Config.Api.RateLimiting.Enabled {
+			kongPlugins += ",rate-limiting"
+			rateLimiting = newKongRateLimiting(utils.Config.Api.RateLimiting.Key, utils.Config.Api.RateLimiting.RequestsPerSecond)
+		}
 		var kongConfigBuf bytes.Buffer
 		if err := kongConfigTemplate.Execute(&kongConfigBuf, kongConfig{
 			GotrueId:      utils.GotrueId,
@@ -288,6 +285,8 @@ EOF
 			EdgeRuntimeId: utils.EdgeRuntimeId,
 			LogflareId:    utils.LogflareId,
 			ApiPort:       utils.Config.Api.Port,
+			RealtimePort:  utils.Config.Realtime.Port,
+			RateLimiting:  rateLimiting,
 		}); err != nil {
 			return err
 		}
@@ -317,7 +316,7 @@ EOF
 					"KONG_DATABASE=off",
 					"KONG_DECLARATIVE_CONFIG=/home/kong/kong.yml",
 					"KONG_DNS_ORDER=LAST,A,CNAME", // https://github.com/supabase/cli/issues/14
-					"KONG_PLUGINS=request-transformer,cors",
+					"KONG_PLUGINS=" + kongPlugins,
 					// Need to increase the nginx buffers in kong to avoid it rejecting the rather
 					// sizeable response headers azure can generate
 					// Ref: https://github.com/Kong/kong/issues/3974#issuecomment-482105126
@@ -346,146 +345,7 @@ EOF
 
 	// Start GoTrue.
 	if utils.Config.Auth.Enabled && !isContainerExcluded(utils.GotrueImage, excluded) {
-		var testOTP bytes.Buffer
-		if len(utils.Config.Auth.Sms.TestOTP) > 0 {
-			encoder := json.NewEncoder(&testOTP)
-			if err := encoder.Encode(utils.Config.Auth.Sms.TestOTP); err != nil {
-				return err
-			}
-		}
-		env := []string{
-			fmt.Sprintf("API_EXTERNAL_URL=http://localhost:%v", utils.Config.Api.Port),
-
-			"GOTRUE_API_HOST=0.0.0.0",
-			"GOTRUE_API_PORT=9999",
-
-			"GOTRUE_DB_DRIVER=postgres",
-			fmt.Sprintf("GOTRUE_DB_DATABASE_URL=postgresql://supabase_auth_admin:%s@%s:%d/%s", dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Database),
-
-			"GOTRUE_SITE_URL=" + utils.Config.Auth.SiteUrl,
-			"GOTRUE_URI_ALLOW_LIST=" + strings.Join(utils.Config.Auth.AdditionalRedirectUrls, ","),
-			fmt.Sprintf("GOTRUE_DISABLE_SIGNUP=%v", !utils.Config.Auth.EnableSignup),
-
-			"GOTRUE_JWT_ADMIN_ROLES=service_role",
-			"GOTRUE_JWT_AUD=authenticated",
-			"GOTRUE_JWT_DEFAULT_GROUP_NAME=authenticated",
-			fmt.Sprintf("GOTRUE_JWT_EXP=%v", utils.Config.Auth.JwtExpiry),
-			"GOTRUE_JWT_SECRET=" + utils.Config.Auth.JwtSecret,
-
-			fmt.Sprintf("GOTRUE_EXTERNAL_EMAIL_ENABLED=%v", utils.Config.Auth.Email.EnableSignup),
-			fmt.Sprintf("GOTRUE_MAILER_SECURE_EMAIL_CHANGE_ENABLED=%v", utils.Config.Auth.Email.DoubleConfirmChanges),
-			fmt.Sprintf("GOTRUE_MAILER_AUTOCONFIRM=%v", !utils.Config.Auth.Email.EnableConfirmations),
-
-			"GOTRUE_SMTP_HOST=" + utils.InbucketId,
-			"GOTRUE_SMTP_PORT=2500",
-			"GOTRUE_SMTP_ADMIN_EMAIL=admin@email.com",
-			"GOTRUE_SMTP_MAX_FREQUENCY=1s",
-			// TODO: To be reverted to `/auth/v1/verify` once
-			// https://github.com/supabase/supabase/issues/16100
-			// is fixed on upstream GoTrue.
-			fmt.Sprintf("GOTRUE_MAILER_URLPATHS_INVITE=http://localhost:%v/auth/v1/verify", utils.Config.Api.Port),
-			fmt.Sprintf("GOTRUE_MAILER_URLPATHS_CONFIRMATION=http://localhost:%v/auth/v1/verify", utils.Config.Api.Port),
-			fmt.Sprintf("GOTRUE_MAILER_URLPATHS_RECOVERY=http://localhost:%v/auth/v1/verify", utils.Config.Api.Port),
-			fmt.Sprintf("GOTRUE_MAILER_URLPATHS_EMAIL_CHANGE=http://localhost:%v/auth/v1/verify", utils.Config.Api.Port),
-			"GOTRUE_RATE_LIMIT_EMAIL_SENT=360000",
-
-			fmt.Sprintf("GOTRUE_EXTERNAL_PHONE_ENABLED=%v", utils.Config.Auth.Sms.EnableSignup),
-			fmt.Sprintf("GOTRUE_SMS_AUTOCONFIRM=%v", !utils.Config.Auth.Sms.EnableConfirmations),
-			"GOTRUE_SMS_MAX_FREQUENCY=5s",
-			"GOTRUE_SMS_OTP_EXP=6000",
-			"GOTRUE_SMS_OTP_LENGTH=6",
-			"GOTRUE_SMS_TEMPLATE=Your code is {{ .Code }}",
-			"GOTRUE_SMS_TEST_OTP=" + testOTP.String(),
-
-			fmt.Sprintf("GOTRUE_SECURITY_REFRESH_TOKEN_ROTATION_ENABLED=%v", utils.Config.Auth.EnableRefreshTokenRotation),
-			fmt.Sprintf("GOTRUE_SECURITY_REFRESH_TOKEN_REUSE_INTERVAL=%v", utils.Config.Auth.RefreshTokenReuseInterval),
-		}
-
-		for id, tmpl := range utils.Config.Auth.Email.Template {
-			if len(tmpl.ContentPath) > 0 {
-				env = append(env, fmt.Sprintf("GOTRUE_MAILER_TEMPLATES_%s=http://%s:%d/email/%s",
-					strings.ToUpper(id),
-					utils.KongId,
-					nginxTemplateServerPort,
-					id+filepath.Ext(tmpl.ContentPath),
-				))
-			}
-			if len(tmpl.Subject) > 0 {
-				env = append(env, fmt.Sprintf("GOTRUE_MAILER_SUBJECTS_%s=%s",
-					strings.ToUpper(id),
-					tmpl.Subject,
-				))
-			}
-		}
-
-		if utils.Config.Auth.Sms.Twilio.Enabled {
-			env = append(
-				env,
-				"GOTRUE_SMS_PROVIDER=twilio",
-				"GOTRUE_SMS_TWILIO_ACCOUNT_SID="+utils.Config.Auth.Sms.Twilio.AccountSid,
-				"GOTRUE_SMS_TWILIO_AUTH_TOKEN="+utils.Config.Auth.Sms.Twilio.AuthToken,
-				"GOTRUE_SMS_TWILIO_MESSAGE_SERVICE_SID="+utils.Config.Auth.Sms.Twilio.MessageServiceSid,
-			)
-		}
-		if utils.Config.Auth.Sms.TwilioVerify.Enabled {
-			env = append(
-				env,
-				"GOTRUE_SMS_PROVIDER=twilio_verify",
-				"GOTRUE_SMS_TWILIO_VERIFY_ACCOUNT_SID="+utils.Config.Auth.Sms.TwilioVerify.AccountSid,
-				"GOTRUE_SMS_TWILIO_VERIFY_AUTH_TOKEN="+utils.Config.Auth.Sms.TwilioVerify.AuthToken,
-				"GOTRUE_SMS_TWILIO_VERIFY_MESSAGE_SERVICE_SID="+utils.Config.Auth.Sms.TwilioVerify.MessageServiceSid,
-			)
-		}
-		if utils.Config.Auth.Sms.Messagebird.Enabled {
-			env = append(
-				env,
-				"GOTRUE_SMS_PROVIDER=messagebird",
-				"GOTRUE_SMS_MESSAGEBIRD_ACCESS_KEY="+utils.Config.Auth.Sms.Messagebird.AccessKey,
-				"GOTRUE_SMS_MESSAGEBIRD_ORIGINATOR="+utils.Config.Auth.Sms.Messagebird.Originator,
-			)
-		}
-		if utils.Config.Auth.Sms.Textlocal.Enabled {
-			env = append(
-				env,
-				"GOTRUE_SMS_PROVIDER=textlocal",
-				"GOTRUE_SMS_TEXTLOCAL_API_KEY="+utils.Config.Auth.Sms.Textlocal.ApiKey,
-				"GOTRUE_SMS_TEXTLOCAL_SENDER="+utils.Config.Auth.Sms.Textlocal.Sender,
-			)
-		}
-		if utils.Config.Auth.Sms.Vonage.Enabled {
-			env = append(
-				env,
-				"GOTRUE_SMS_PROVIDER=vonage",
-				"GOTRUE_SMS_VONAGE_API_KEY="+utils.Config.Auth.Sms.Vonage.ApiKey,
-				"GOTRUE_SMS_VONAGE_API_SECRET="+utils.Config.Auth.Sms.Vonage.ApiSecret,
-				"GOTRUE_SMS_VONAGE_FROM="+utils.Config.Auth.Sms.Vonage.From,
-			)
-		}
-
-		for name, config := range utils.Config.Auth.External {
-			env = append(
-				env,
-				fmt.Sprintf("GOTRUE_EXTERNAL_%s_ENABLED=%v", strings.ToUpper(name), config.Enabled),
-				fmt.Sprintf("GOTRUE_EXTERNAL_%s_CLIENT_ID=%s", strings.ToUpper(name), config.ClientId),
-				fmt.Sprintf("GOTRUE_EXTERNAL_%s_SECRET=%s", strings.ToUpper(name), config.Secret),
-			)
-
-			if config.RedirectUri != "" {
-				env = append(env,
-					fmt.Sprintf("GOTRUE_EXTERNAL_%s_REDIRECT_URI=%s", strings.ToUpper(name), config.RedirectUri),
-				)
-			} else {
-				env = append(env,
-					fmt.Sprintf("GOTRUE_EXTERNAL_%s_REDIRECT_URI=http://localhost:%v/auth/v1/callback", strings.ToUpper(name), utils.Config.Api.Port),
-				)
-			}
-
-			if config.Url != "" {
-				env = append(env,
-					fmt.Sprintf("GOTRUE_EXTERNAL_%s_URL=%s", strings.ToUpper(name), config.Url),
-				)
-			}
-		}
+		env := GotrueEnv(dbConfig)
 
 		if _, err := utils.DockerStart(
 			ctx,
@@ -547,32 +407,14 @@ EOF
 			ctx,
 			container.Config{
 				Image: utils.RealtimeImage,
-				Env: []string{
-					"PORT=4000",
-					"DB_HOST=" + dbConfig.Host,
-					fmt.Sprintf("DB_PORT=%d", dbConfig.Port),
-					"DB_USER=supabase_admin",
-					"DB_PASSWORD=" + dbConfig.Password,
-					"DB_NAME=" + dbConfig.Database,
-					"DB_AFTER_CONNECT_QUERY=SET search_path TO _realtime",
-					"DB_ENC_KEY=supabaserealtime",
-					"API_JWT_SECRET=" + utils.Config.Auth.JwtSecret,
-					"FLY_ALLOC_ID=abc123",
-					"FLY_APP_NAME=realtime",
-					"SECRET_KEY_BASE=EAx3IQ/wRG1v47ZD4NE4/9RzBI8Jmil3x0yhcW4V2NHBP6c2iPIzwjofi2Ep4HIG",
-					"ERL_AFLAGS=-proto_dist inet_tcp",
-					"ENABLE_TAILSCALE=false",
-					"DNS_NODES=''",
-					"RLIMIT_NOFILE=",
-					"REALTIME_IP_VERSION=" + string(utils.Config.Realtime.IpVersion),
-				},
+				Env:   RealtimeEnv(dbConfig),
 				Cmd: []string{
 					"/bin/sh", "-c",
 					"/app/bin/migrate && /app/bin/realtime eval 'Realtime.Release.seeds(Realtime.Repo)' && /app/bin/server",
 				},
-				ExposedPorts: nat.PortSet{"4000/tcp": {}},
+				ExposedPorts: nat.PortSet{nat.Port(fmt.Sprintf("%d/tcp", utils.Config.Realtime.Port)): {}},
 				Healthcheck: &container.HealthConfig{
-					Test:     []string{"CMD", "bash", "-c", "printf \\0 > /dev/tcp/localhost/4000"},
+					Test:     []string{"CMD", "bash", "-c", fmt.Sprintf("printf \\0 > /dev/tcp/localhost/%d", utils.Config.Realtime.Port)},
 					Interval: 10 * time.Second,
 					Timeout:  2 * time.Second,
 					Retries:  3,
@@ -590,18 +432,18 @@ EOF
 
 	// Start PostgREST.
 	if utils.Config.Api.Enabled && !isContainerExcluded(utils.PostgrestImage, excluded) {
+		if err := resolveApiSchemas(ctx, dbConfig, options...); err != nil {
+			return err
+		}
+		jwtSecret, err := resolveJwtSecret(ctx)
+		if err != nil {
+			return err
+		}
 		if _, err := utils.DockerStart(
 			ctx,
 			container.Config{
 				Image: utils.PostgrestImage,
-				Env: []string{
-					fmt.Sprintf("PGRST_DB_URI=postgresql://authenticator:%s@%s:%d/%s", dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Database),
-					"PGRST_DB_SCHEMAS=" + strings.Join(utils.Config.Api.Schemas, ","),
-					"PGRST_DB_EXTRA_SEARCH_PATH=" + strings.Join(utils.Config.Api.ExtraSearchPath, ","),
-					fmt.Sprintf("PGRST_DB_MAX_ROWS=%d", utils.Config.Api.MaxRows),
-					"PGRST_DB_ANON_ROLE=anon",
-					"PGRST_JWT_SECRET=" + utils.Config.Auth.JwtSecret,
-				},
+				Env:   PostgrestEnv(dbConfig, jwtSecret),
 				// PostgREST does not expose a shell for health check
 			},
 			start.WithSyslogConfig(container.HostConfig{
@@ -616,27 +458,11 @@ EOF
 
 	// Start Storage.
 	if utils.Config.Storage.Enabled && !isContainerExcluded(utils.StorageImage, excluded) {
-		dockerStoragePath := "/mnt"
 		if _, err := utils.DockerStart(
 			ctx,
 			container.Config{
 				Image: utils.StorageImage,
-				Env: []string{
-					"ANON_KEY=" + utils.Config.Auth.AnonKey,
-					"SERVICE_KEY=" + utils.Config.Auth.ServiceRoleKey,
-					"POSTGREST_URL=http://" + utils.RestId + ":3000",
-					"PGRST_JWT_SECRET=" + utils.Config.Auth.JwtSecret,
-					fmt.Sprintf("DATABASE_URL=postgresql://supabase_storage_admin:%s@%s:%d/%s", dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Database),
-					fmt.Sprintf("FILE_SIZE_LIMIT=%v", utils.Config.Storage.FileSizeLimit),
-					"STORAGE_BACKEND=file",
-					"FILE_STORAGE_BACKEND_PATH=" + dockerStoragePath,
-					"TENANT_ID=stub",
-					// TODO: https://github.com/supabase/storage-api/issues/55
-					"REGION=stub",
-					"GLOBAL_S3_BUCKET=stub",
-					"ENABLE_IMAGE_TRANSFORMATION=true",
-					"IMGPROXY_URL=http://" + utils.ImgProxyId + ":5001",
-				},
+				Env:   StorageEnv(dbConfig),
 				Healthcheck: &container.HealthConfig{
 					// For some reason, localhost resolves to IPv6 address on GitPod which breaks healthcheck.
 					Test:     []string{"CMD", "wget", "--no-verbose", "--tries=1", "--spider", "http://127.0.0.1:5000/status"},
@@ -657,16 +483,28 @@ EOF
 	}
 
 	// Start Storage ImgProxy.
-	if utils.Config.Storage.Enabled && !isContainerExcluded(utils.ImageProxyImage, excluded) {
+	if utils.Config.Storage.Enabled && utils.Config.Storage.ImageTransformation.Enabled && !isContainerExcluded(utils.ImageProxyImage, excluded) {
+		env := []string{
+			"IMGPROXY_BIND=:5001",
+			"IMGPROXY_LOCAL_FILESYSTEM_ROOT=/",
+			"IMGPROXY_USE_ETAG=/",
+			fmt.Sprintf("IMGPROXY_MAX_SRC_RESOLUTION=%d", utils.Config.Storage.ImageTransformation.MaxResolution),
+		}
+		// Storage passes s3:// source urls to imgproxy when using the s3 backend
+		if utils.Config.Storage.Backend == utils.StorageBackendS3 {
+			env = append(env,
+				"IMGPROXY_USE_S3=true",
+				"IMGPROXY_S3_ENDPOINT="+utils.Config.Storage.S3.Endpoint,
+				"IMGPROXY_S3_REGION="+utils.Config.Storage.S3.Region,
+				"AWS_ACCESS_KEY_ID="+utils.Config.Storage.S3.AccessKey,
+				"AWS_SECRET_ACCESS_KEY="+utils.Config.Storage.S3.SecretKey,
+			)
+		}
 		if _, err := utils.DockerStart(
 			ctx,
 			container.Config{
 				Image: utils.ImageProxyImage,
-				Env: []string{
-					"IMGPROXY_BIND=:5001",
-					"IMGPROXY_LOCAL_FILESYSTEM_ROOT=/",
-					"IMGPROXY_USE_ETAG=/",
-				},
+				Env:   env,
 				Healthcheck: &container.HealthConfig{
 					Test:     []string{"CMD", "imgproxy", "health"},
 					Interval: 10 * time.Second,
@@ -686,7 +524,7 @@ EOF
 	}
 
 	// Start all functions.
-	if !isContainerExcluded(utils.EdgeRuntimeImage, excluded) {
+	if utils.Config.EdgeRuntime.Enabled && !isContainerExcluded(utils.EdgeRuntimeImage, excluded) {
 		dbUrl := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s", dbConfig.User, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Database)
 		if err := serve.ServeFunctions(ctx, "", nil, "", dbUrl, w, fsys); err != nil {
 			return err
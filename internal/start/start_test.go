@@ -1,6 +1,7 @@
 package start
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"net/http"
@@ -38,7 +39,7 @@ func TestStartCommand(t *testing.T) {
 	t.Run("throws error on missing docker", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
 		defer gock.OffAll()
@@ -58,7 +59,7 @@ func TestStartCommand(t *testing.T) {
 	t.Run("noop if database is already running", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
 		defer gock.OffAll()
@@ -230,4 +231,89 @@ func TestDatabaseStart(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
+
+	t.Run("skips imgproxy when storage is disabled", func(t *testing.T) {
+		original := utils.Config.Storage
+		defer func() { utils.Config.Storage = original }()
+		utils.Config.Storage.Enabled = false
+		utils.Config.Storage.ImageTransformation.Enabled = true
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/networks/create").
+			Reply(http.StatusCreated).
+			JSON(types.NetworkCreateResponse{})
+		// Caches all dependencies
+		utils.DbImage = utils.Pg15Image
+		imageUrl := utils.GetRegistryImageUrl(utils.DbImage)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/" + imageUrl + "/json").
+			Reply(http.StatusOK).
+			JSON(types.ImageInspect{})
+		// Start postgres
+		utils.DbId = "test-postgres"
+		utils.Config.Db.Port = 54322
+		utils.Config.Db.MajorVersion = 15
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/volumes/" + utils.DbId).
+			Reply(http.StatusOK).
+			JSON(volume.Volume{})
+		apitest.MockDockerStart(utils.Docker, imageUrl, utils.DbId)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.DbId + "/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{
+				State: &types.ContainerState{
+					Running: true,
+					Health:  &types.Health{Status: "healthy"},
+				},
+			}})
+		// Exclude every service except imgproxy, so any unexpected container start fails
+		var exclude []string
+		for _, name := range ExcludableContainers() {
+			if name != utils.ShortContainerImageName(utils.ImageProxyImage) {
+				exclude = append(exclude, name)
+			}
+		}
+		// Run test
+		err := utils.RunProgram(context.Background(), func(p utils.Program, ctx context.Context) error {
+			return run(p, context.Background(), fsys, exclude, pgconn.Config{Host: utils.DbId})
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestKongConfig(t *testing.T) {
+	t.Run("renders rate limiting plugin", func(t *testing.T) {
+		var buf bytes.Buffer
+		// Run test
+		err := kongConfigTemplate.Execute(&buf, kongConfig{
+			RateLimiting: newKongRateLimiting(utils.RateLimitByUser, 2.5),
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `
+plugins:
+  - name: rate-limiting
+    config:
+      second: 2.5
+      policy: local
+      limit_by: header
+      header_name: Authorization
+`)
+	})
+
+	t.Run("omits rate limiting by default", func(t *testing.T) {
+		var buf bytes.Buffer
+		// Run test
+		err := kongConfigTemplate.Execute(&buf, kongConfig{})
+		// Check error
+		assert.NoError(t, err)
+		assert.NotContains(t, buf.String(), "rate-limiting")
+	})
 }
@@ -0,0 +1,27 @@
+package start
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Validates config.toml on every change while local containers are running. Changes are not
+// applied to the running containers.
+func WatchConfig(ctx context.Context, fsys afero.Fs) error {
+	return utils.WatchConfig(ctx, func() {
+		checkConfig(fsys, os.Stderr)
+	})
+}
+
+func checkConfig(fsys afero.Fs, w io.Writer) {
+	if err := utils.ValidateAll(fsys); err != nil {
+		fmt.Fprintln(w, utils.Red(err.Error()))
+		return
+	}
+	fmt.Fprintln(w, "Config changed. Run "+utils.Aqua("supabase stop && supabase start")+" to apply.")
+}
@@ -0,0 +1,42 @@
+package start
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestCheckConfig(t *testing.T) {
+	t.Run("prints restart banner on valid config", func(t *testing.T) {
+		var out bytes.Buffer
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		checkConfig(fsys, &out)
+		// Check output
+		assert.Contains(t, out.String(), "Config changed. Run")
+	})
+
+	t.Run("prints validation errors", func(t *testing.T) {
+		var out bytes.Buffer
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"
+[api]
+port = 0
+[db]
+port = 0
+`), 0644))
+		// Run test
+		checkConfig(fsys, &out)
+		// Check output
+		assert.Contains(t, out.String(), "Missing required field in config: api.port")
+		assert.Contains(t, out.String(), "Missing required field in config: db.port")
+		assert.NotContains(t, out.String(), "Config changed.")
+	})
+}
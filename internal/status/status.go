@@ -57,19 +57,7 @@ func Run(ctx context.Context, names CustomName, format string, fsys afero.Fs) er
 		}
 	}
 
-	services := []string{
-		utils.KongId,
-		utils.GotrueId,
-		utils.InbucketId,
-		utils.RealtimeId,
-		utils.RestId,
-		utils.StorageId,
-		utils.ImgProxyId,
-		utils.PgmetaId,
-		utils.StudioId,
-		utils.LogflareId,
-	}
-	stopped := checkServiceHealth(ctx, services, os.Stderr)
+	stopped := checkServiceHealth(ctx, enabledServices(), os.Stderr)
 	if len(stopped) > 0 {
 		fmt.Fprintln(os.Stderr, "Stopped services:", stopped)
 	}
@@ -81,6 +69,37 @@ func Run(ctx context.Context, names CustomName, format string, fsys afero.Fs) er
 	return printStatus(names, format, os.Stdout, stopped...)
 }
 
+// Returns the containers that supabase start runs for the current config, so that disabled
+// services are not reported as stopped.
+func enabledServices() []string {
+	services := []string{utils.KongId}
+	if utils.Config.Auth.Enabled {
+		services = append(services, utils.GotrueId)
+	}
+	if utils.Config.Inbucket.Enabled {
+		services = append(services, utils.InbucketId)
+	}
+	if utils.Config.Realtime.Enabled {
+		services = append(services, utils.RealtimeId)
+	}
+	if utils.Config.Api.Enabled {
+		services = append(services, utils.RestId)
+	}
+	if utils.Config.Storage.Enabled {
+		services = append(services, utils.StorageId)
+	}
+	if utils.Config.Storage.ImageTransformation.Enabled {
+		services = append(services, utils.ImgProxyId)
+	}
+	if utils.Config.Studio.Enabled {
+		services = append(services, utils.PgmetaId, utils.StudioId)
+	}
+	if utils.Config.Analytics.Enabled {
+		services = append(services, utils.LogflareId)
+	}
+	return services
+}
+
 func checkServiceHealth(ctx context.Context, services []string, w io.Writer) (stopped []string) {
 	for _, name := range services {
 		if err := AssertContainerHealthy(ctx, name); err != nil {
@@ -36,7 +36,40 @@ func TestStatusCommand(t *testing.T) {
 		}
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		for _, container := range services {
+			gock.New(utils.Docker.DaemonHost()).
+				Get("/v" + utils.Docker.ClientVersion() + "/containers/" + container).
+				Reply(http.StatusOK).
+				JSON(types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{
+					State: &types.ContainerState{Running: true},
+				}})
+		}
+		// Run test
+		assert.NoError(t, Run(context.Background(), CustomName{}, utils.OutputPretty, fsys))
+		// Check error
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips disabled services", func(t *testing.T) {
+		services := []string{
+			"supabase_db_",
+			"supabase_kong_",
+			"supabase_auth_",
+			"supabase_inbucket_",
+			"supabase_rest_",
+			"supabase_pg_meta_",
+			"supabase_studio_",
+			"supabase_analytics_",
+		}
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
+		require.NoError(t, utils.SetConfigValue("storage.enabled", "false", fsys))
+		require.NoError(t, utils.SetConfigValue("realtime.enabled", "false", fsys))
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
 		defer gock.OffAll()
@@ -72,7 +105,7 @@ func TestStatusCommand(t *testing.T) {
 	t.Run("throws error on missing docker", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
 		defer gock.OffAll()
@@ -125,7 +158,7 @@ func TestServiceHealth(t *testing.T) {
 	t.Run("throws error on missing container", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, utils.InitConfig(utils.InitParams{}, fsys))
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(utils.Docker))
 		defer gock.OffAll()
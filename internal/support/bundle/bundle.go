@@ -0,0 +1,206 @@
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	logTailLines  = "200"
+	maxCrashDumps = 5
+)
+
+type bundleFile struct {
+	Name     string
+	Contents []byte
+}
+
+func Run(ctx context.Context, output string, fsys afero.Fs, stdin *os.File) error {
+	// Loading config also registers its secrets with the redaction filter
+	configErr := utils.LoadConfigFS(fsys)
+	files := []bundleFile{collectVersion()}
+	if configErr == nil {
+		contents, err := utils.SanitizedConfig(fsys)
+		if err != nil {
+			return err
+		}
+		files = append(files, bundleFile{Name: "config.toml", Contents: contents})
+	}
+	containers, dockerErr := listContainers(ctx)
+	files = append(files, collectDoctor(configErr, dockerErr))
+	if dockerErr == nil {
+		docker, err := collectDocker(ctx, containers)
+		if err != nil {
+			return err
+		}
+		files = append(files, docker)
+		var running []types.Container
+		for _, c := range containers {
+			if c.State == "running" {
+				running = append(running, c)
+			}
+		}
+		if len(running) > 0 && utils.PromptYesNo("Include the last "+logTailLines+" log lines of each running service? Logs may contain user data.", false, stdin) {
+			logs, err := collectLogs(ctx, running)
+			if err != nil {
+				return err
+			}
+			files = append(files, logs...)
+		}
+	}
+	dumps, err := collectCrashDumps(fsys)
+	if err != nil {
+		return err
+	}
+	files = append(files, dumps...)
+	if err := writeBundle(output, files, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Support bundle includes:")
+	for _, file := range files {
+		fmt.Fprintln(os.Stderr, "  "+file.Name)
+	}
+	fmt.Println("Saved support bundle to " + utils.Bold(output))
+	return nil
+}
+
+func collectVersion() bundleFile {
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "Version:", utils.Version)
+	fmt.Fprintf(&buf, "Platform: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	return bundleFile{Name: "version.txt", Contents: buf.Bytes()}
+}
+
+func collectDoctor(configErr, dockerErr error) bundleFile {
+	var buf bytes.Buffer
+	for _, check := range []struct {
+		name string
+		err  error
+	}{
+		{"Load config", configErr},
+		{"Connect docker", dockerErr},
+	} {
+		if check.err != nil {
+			fmt.Fprintf(&buf, "%s: FAIL %v\n", check.name, check.err)
+		} else {
+			fmt.Fprintf(&buf, "%s: OK\n", check.name)
+		}
+	}
+	return bundleFile{Name: "doctor.txt", Contents: []byte(utils.RedactSecrets(buf.String()))}
+}
+
+func listContainers(ctx context.Context) ([]types.Container, error) {
+	return utils.Docker.ContainerList(ctx, types.ContainerListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", "com.supabase.cli.project")),
+	})
+}
+
+func collectDocker(ctx context.Context, containers []types.Container) (bundleFile, error) {
+	version, err := utils.Docker.ServerVersion(ctx)
+	if err != nil {
+		return bundleFile{}, err
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Engine: %s %s (%s/%s)\n", version.Platform.Name, version.Version, version.Os, version.Arch)
+	for _, component := range version.Components {
+		fmt.Fprintf(&buf, "Component: %s %s\n", component.Name, component.Version)
+	}
+	fmt.Fprintln(&buf, "\nContainers:")
+	for _, c := range containers {
+		fmt.Fprintf(&buf, "%s\t%s\t%s\t%s\n", strings.Join(c.Names, ","), c.Image, c.State, c.Status)
+	}
+	return bundleFile{Name: "docker.txt", Contents: buf.Bytes()}, nil
+}
+
+func collectLogs(ctx context.Context, containers []types.Container) ([]bundleFile, error) {
+	var files []bundleFile
+	for _, c := range containers {
+		logs, err := utils.Docker.ContainerLogs(ctx, c.ID, types.ContainerLogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Tail:       logTailLines,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		_, err = stdcopy.StdCopy(&buf, &buf, logs)
+		logs.Close()
+		if err != nil {
+			return nil, err
+		}
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		files = append(files, bundleFile{
+			Name:     "logs/" + name + ".log",
+			Contents: []byte(utils.RedactSecrets(buf.String())),
+		})
+	}
+	return files, nil
+}
+
+func collectCrashDumps(fsys afero.Fs) ([]bundleFile, error) {
+	entries, err := afero.ReadDir(fsys, utils.CrashDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	// Crash dumps are named by timestamp so the most recent sorts last
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() > entries[j].Name()
+	})
+	var files []bundleFile
+	for _, entry := range entries {
+		if len(files) >= maxCrashDumps {
+			break
+		}
+		if entry.IsDir() {
+			continue
+		}
+		contents, err := afero.ReadFile(fsys, filepath.Join(utils.CrashDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, bundleFile{
+			Name:     "crash/" + entry.Name(),
+			Contents: []byte(utils.RedactSecrets(string(contents))),
+		})
+	}
+	return files, nil
+}
+
+func writeBundle(output string, files []bundleFile, fsys afero.Fs) error {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := zw.Create(file.Name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(w, bytes.NewReader(file.Contents)); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return utils.WriteFile(output, buf.Bytes(), fsys)
+}
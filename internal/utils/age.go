@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/joho/godotenv"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils/credentials"
+)
+
+// Secrets in .env.age are encrypted at rest with age, either to a passphrase or to the public key
+// of an age identity. They are only ever decrypted in memory when loading config, and never
+// override variables already set in the environment. The passphrase or identity is read from the
+// environment so that CI can load secrets without a prompt.
+const (
+	AgePassphraseEnv = "SUPABASE_AGE_PASSPHRASE"
+	AgeIdentityEnv   = "SUPABASE_AGE_IDENTITY"
+)
+
+func PromptAgePassphrase(label string) string {
+	fmt.Fprint(os.Stderr, label)
+	return strings.TrimSpace(credentials.PromptMasked(os.Stdin))
+}
+
+// Returns identities for decrypting .env.age, preferring an age secret key over a passphrase.
+func AgeIdentities() ([]age.Identity, error) {
+	if key := os.Getenv(AgeIdentityEnv); len(key) > 0 {
+		identities, err := age.ParseIdentities(strings.NewReader(key))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s: %w", AgeIdentityEnv, err)
+		}
+		return identities, nil
+	}
+	passphrase := os.Getenv(AgePassphraseEnv)
+	if len(passphrase) == 0 {
+		passphrase = PromptAgePassphrase("Enter passphrase for " + Bold(EncryptedEnvPath) + ": ")
+	}
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return []age.Identity{identity}, nil
+}
+
+// Returns the recipient for encrypting .env, falling back to a passphrase when no public key is given.
+func AgeRecipient(publicKey string) (age.Recipient, error) {
+	if len(publicKey) > 0 {
+		recipient, err := age.ParseX25519Recipient(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid recipient: %w", err)
+		}
+		return recipient, nil
+	}
+	passphrase := os.Getenv(AgePassphraseEnv)
+	if len(passphrase) == 0 {
+		passphrase = PromptAgePassphrase("Enter a passphrase for " + Bold(EncryptedEnvPath) + ": ")
+		if confirm := PromptAgePassphrase("Confirm passphrase: "); confirm != passphrase {
+			return nil, errors.New("Passphrases do not match.")
+		}
+	}
+	return age.NewScryptRecipient(passphrase)
+}
+
+// Decrypts the contents of .env.age in memory.
+func DecryptEnv(r io.Reader) (io.Reader, error) {
+	identities, err := AgeIdentities()
+	if err != nil {
+		return nil, err
+	}
+	decrypted, err := age.Decrypt(r, identities...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", EncryptedEnvPath, err)
+	}
+	return decrypted, nil
+}
+
+func loadEncryptedEnv(fsys afero.Fs) error {
+	f, err := fsys.Open(EncryptedEnvPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+	decrypted, err := DecryptEnv(f)
+	if err != nil {
+		return err
+	}
+	env, err := godotenv.Parse(decrypted)
+	if err != nil {
+		return err
+	}
+	for key, value := range env {
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
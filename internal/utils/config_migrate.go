@@ -0,0 +1,185 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/afero"
+)
+
+// Latest layout of config.toml understood by this CLI. Bump this whenever an entry is added to
+// deprecatedKeys, so that migrated files record the layout they were upgraded to.
+const CurrentConfigVersion = 2
+
+// Upgrades config.toml from older layouts to CurrentConfigVersion with targeted edits that
+// preserve comments. Returns a description of each change, or nil if already up to date.
+func MigrateConfig(fsys afero.Fs) ([]string, error) {
+	original, err := afero.ReadFile(fsys, ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	var decoded map[string]interface{}
+	if _, err := toml.Decode(string(original), &decoded); err != nil {
+		return nil, err
+	}
+	var version uint
+	if v, ok := decoded["config_version"].(int64); ok && v > 0 {
+		version = uint(v)
+	}
+	if version > CurrentConfigVersion {
+		return nil, invalidField("config_version", "%d is newer than the latest supported version %d. Upgrade your Supabase CLI to migrate this config.", version, CurrentConfigVersion)
+	}
+	updated, renamed, err := renameDeprecatedKeys(string(original), deprecatedKeys)
+	if err != nil {
+		return nil, err
+	}
+	var changes []string
+	for _, d := range renamed {
+		changes = append(changes, fmt.Sprintf("Renamed %s to %s", d.Old, d.New))
+	}
+	if version < CurrentConfigVersion {
+		updated = setTomlValue(updated, "", "config_version", fmt.Sprintf("%d", CurrentConfigVersion))
+		changes = append(changes, fmt.Sprintf("Set config_version to %d", CurrentConfigVersion))
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	// Validate the migrated file before overwriting the original
+	overlay := afero.NewCopyOnWriteFs(fsys, afero.NewMemMapFs())
+	if err := afero.WriteFile(overlay, ConfigPath, []byte(updated), 0644); err != nil {
+		return nil, err
+	}
+	if err := LoadConfigFS(overlay); err != nil {
+		return nil, err
+	}
+	if err := afero.WriteFile(fsys, ConfigPath, []byte(updated), 0644); err != nil {
+		return nil, restoreConfig(fsys, original, err)
+	}
+	return changes, nil
+}
+
+// Returns an fs where config.toml is rewritten to the layout expected by the config struct. Deprecated
+// keys are renamed, and functions declared as an array of tables are converted to a table keyed by
+// name. The original file is left untouched.
+func normalizeConfigFs(fsys afero.Fs) (afero.Fs, error) {
+	original, err := afero.ReadFile(fsys, ConfigPath)
+	if err != nil {
+		// Reported when decoding
+		return fsys, nil
+	}
+	updated, renamed, err := renameDeprecatedKeys(string(original), deprecatedKeys)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range renamed {
+		Warnf("%s is deprecated and was loaded as %s. Run %s to update %s.", d.Old, d.New, Aqua("supabase config migrate"), Bold(ConfigPath))
+	}
+	if updated, err = functionArrayToTable(updated); err != nil {
+		return nil, err
+	}
+	if updated == string(original) {
+		return fsys, nil
+	}
+	overlay := afero.NewCopyOnWriteFs(fsys, afero.NewMemMapFs())
+	if err := afero.WriteFile(overlay, ConfigPath, []byte(updated), 0644); err != nil {
+		return nil, err
+	}
+	return overlay, nil
+}
+
+// Moves the values of deprecated keys found in content to their new keys. Returns an error with
+// instructions for deprecations that cannot be applied mechanically.
+func renameDeprecatedKeys(content string, keys []deprecatedKey) (string, []deprecatedKey, error) {
+	var decoded map[string]interface{}
+	if _, err := toml.Decode(content, &decoded); err != nil {
+		// Reported when decoding
+		return content, nil, nil
+	}
+	var renamed []deprecatedKey
+	for _, d := range keys {
+		value, ok := lookupTomlKey(decoded, d.Old)
+		if !ok {
+			continue
+		}
+		if len(d.Manual) > 0 {
+			return content, nil, invalidField(d.Old, "replaced by %s. %s", d.New, d.Manual)
+		}
+		// An explicit new key takes precedence over the deprecated one
+		if _, exists := lookupTomlKey(decoded, d.New); !exists {
+			table, key := splitTomlKey(d.New)
+			content = setTomlValue(content, table, key, tomlValue(value))
+		}
+		table, key := splitTomlKey(d.Old)
+		content = removeTomlValue(content, table, key)
+		renamed = append(renamed, d)
+	}
+	return content, renamed, nil
+}
+
+// Rewrites each [[functions]] entry as a [functions.<name>] table, preserving the order of entries.
+func functionArrayToTable(content string) (string, error) {
+	var decoded map[string]interface{}
+	if _, err := toml.Decode(content, &decoded); err != nil {
+		// Reported when decoding
+		return content, nil
+	}
+	entries, ok := decoded["functions"].([]map[string]interface{})
+	if !ok {
+		return content, nil
+	}
+	var names []string
+	for i, entry := range entries {
+		name, ok := entry["name"].(string)
+		if !ok || len(name) == 0 {
+			return "", invalidField("functions", "entry %d is missing a name", i+1)
+		}
+		if SliceContains(names, name) {
+			return "", invalidField("functions", "%s is declared more than once", name)
+		}
+		names = append(names, name)
+	}
+	lines := strings.Split(content, "\n")
+	result := make([]string, 0, len(lines))
+	namePattern := regexp.MustCompile(`^name\s*=`)
+	index, inEntry := 0, false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			inEntry = trimmed == "[[functions]]"
+			if inEntry {
+				result = append(result, "[functions."+tomlKey(names[index])+"]")
+				index++
+				continue
+			}
+		}
+		if inEntry && namePattern.MatchString(trimmed) {
+			continue
+		}
+		result = append(result, line)
+	}
+	return strings.Join(result, "\n"), nil
+}
+
+func lookupTomlKey(decoded map[string]interface{}, key string) (interface{}, bool) {
+	var current interface{} = decoded
+	for _, part := range strings.Split(key, ".") {
+		table, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = table[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func splitTomlKey(key string) (string, string) {
+	i := strings.LastIndex(key, ".")
+	if i < 0 {
+		return "", key
+	}
+	return key[:i], key[i+1:]
+}
@@ -0,0 +1,218 @@
+package utils
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+//go:generate go run ../../tools/configschema templates/config.schema.json
+
+// JSON schema of config.toml for editor validation and autocomplete. It is regenerated from the
+// config struct by go generate, and a unit test keeps the committed copy in sync.
+//
+//go:embed templates/config.schema.json
+var ConfigSchemaJson string
+
+// Values accepted by string types that are validated as enums.
+var schemaEnums = map[reflect.Type][]interface{}{
+	reflect.TypeOf(LogflareBackend("")): {LogflarePostgres, LogflareBigQuery},
+	reflect.TypeOf(PoolMode("")):        {TransactionMode, SessionMode},
+	reflect.TypeOf(RateLimitKey("")):    {RateLimitByIp, RateLimitByUser, RateLimitByService},
+	reflect.TypeOf(RequestPolicy("")):   {PolicyOneshot, PolicyPerWorker},
+	reflect.TypeOf(StorageBackend("")):  {StorageBackendFile, StorageBackendS3},
+	reflect.TypeOf(JwtAlgorithm("")):    {JwtHS256, JwtRS256, JwtES256},
+	reflect.TypeOf(AddressFamily("")):   {AddressIPv6, AddressIPv4},
+	reflect.TypeOf(LogLevel("")):        {LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError},
+	reflect.TypeOf(LogFormat("")):       {LogFormatText, LogFormatJson},
+}
+
+// Values accepted by individual keys whose type is not specific enough.
+var schemaKeyEnums = map[string][]interface{}{
+	"db.major_version": {13, 14, 15},
+}
+
+// Matches a commented out example, such as `# port = 54326` or `# [functions.my-function]`.
+var commentedExamplePattern = regexp.MustCompile(`^(\[.*\]|[a-z0-9_."-]+ = .*)$`)
+
+// Generates the JSON schema of config.toml by reflecting over the config struct. Descriptions
+// are taken from the comments above each key in the init config template.
+func ConfigSchema() ([]byte, error) {
+	b := schemaBuilder{descriptions: templateDescriptions(initConfigEmbed)}
+	definition := b.build(reflect.TypeOf(config{}), "")
+	definition["properties"].(map[string]interface{})["platform"] = map[string]interface{}{
+		"description": "Overrides applied on top of the base config when running on the given operating system.",
+		"type":        "object",
+		"patternProperties": map[string]interface{}{
+			keyAlternation(platforms): map[string]interface{}{"$ref": "#/definitions/config"},
+		},
+		"additionalProperties": false,
+	}
+	schema := map[string]interface{}{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"title":       "Supabase CLI config",
+		"allOf":       []interface{}{map[string]interface{}{"$ref": "#/definitions/config"}},
+		"required":    []string{"project_id"},
+		"definitions": map[string]interface{}{"config": definition},
+	}
+	encoded, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(encoded, '\n'), nil
+}
+
+type schemaBuilder struct {
+	descriptions map[string]string
+}
+
+func (b schemaBuilder) build(t reflect.Type, path string) map[string]interface{} {
+	result := map[string]interface{}{}
+	if desc, ok := b.descriptions[path]; ok {
+		result["description"] = desc
+	}
+	if enum, ok := schemaKeyEnums[path]; ok {
+		result["enum"] = enum
+		return result
+	}
+	if enum, ok := schemaEnums[t]; ok {
+		result["type"] = "string"
+		result["enum"] = enum
+		return result
+	}
+	switch t {
+	case reflect.TypeOf(sizeInBytes(0)):
+		// Either a number of bytes or a human readable size, such as 5MB
+		result["type"] = []string{"integer", "string"}
+		return result
+	case reflect.TypeOf(durationInSeconds(0)):
+		// Either a number of seconds or a duration string, such as 1h
+		result["type"] = []string{"integer", "string"}
+		return result
+	case reflect.TypeOf(durationInMilliseconds(0)):
+		// Either a number of milliseconds or a duration string, such as 30s
+		result["type"] = []string{"integer", "string"}
+		return result
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return b.build(t.Elem(), path)
+	case reflect.Bool:
+		result["type"] = "boolean"
+	case reflect.String:
+		result["type"] = "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result["type"] = "integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		result["type"] = "integer"
+		result["minimum"] = 0
+		if t.Kind() == reflect.Uint16 {
+			result["maximum"] = 65535
+		}
+	case reflect.Float32, reflect.Float64:
+		result["type"] = "number"
+	case reflect.Slice:
+		result["type"] = "array"
+		result["items"] = b.build(t.Elem(), path+".*")
+	case reflect.Map:
+		result["type"] = "object"
+		// Known providers are listed for autocomplete, while custom providers are passed through
+		if path == "auth.external" {
+			properties := map[string]interface{}{}
+			for _, name := range externalProviders {
+				properties[name] = b.build(t.Elem(), path+"."+name)
+			}
+			// Apple accepts fields that other providers don't
+			properties["apple"] = b.build(reflect.TypeOf(apple{}), path+".apple")
+			result["properties"] = properties
+			result["additionalProperties"] = b.build(t.Elem(), path+".*")
+			break
+		}
+		result["patternProperties"] = map[string]interface{}{
+			schemaKeyPattern(path): b.build(t.Elem(), path+".*"),
+		}
+		result["additionalProperties"] = false
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		b.addProperties(properties, t, path)
+		result["type"] = "object"
+		result["properties"] = properties
+		result["additionalProperties"] = false
+	}
+	return result
+}
+
+func (b schemaBuilder) addProperties(properties map[string]interface{}, t reflect.Type, path string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("toml"), ",")[0]
+		if field.Anonymous && len(tag) == 0 {
+			b.addProperties(properties, field.Type, path)
+			continue
+		}
+		if len(tag) == 0 || tag == "-" {
+			continue
+		}
+		key := tag
+		if len(path) > 0 {
+			key = path + "." + tag
+		}
+		properties[tag] = b.build(field.Type, key)
+	}
+}
+
+// Returns the pattern of keys accepted by a free-form table.
+func schemaKeyPattern(path string) string {
+	switch path {
+	case "functions":
+		return FuncSlugPattern.String()
+	case "auth.hook":
+		return keyAlternation(authHooks)
+	case "auth.sms.test_otp":
+		return e164Pattern.String()
+	}
+	return "^.+$"
+}
+
+func keyAlternation(keys []string) string {
+	return fmt.Sprintf("^(%s)$", strings.Join(keys, "|"))
+}
+
+// Collects the comment block directly above each key and table in a config template, keyed by
+// the dotted path. Commented out examples end a block instead of being part of it.
+func templateDescriptions(template string) map[string]string {
+	result := map[string]string{}
+	var table string
+	var comments []string
+	for _, line := range strings.Split(template, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			text := strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+			if commentedExamplePattern.MatchString(text) {
+				comments = nil
+			} else if len(text) > 0 {
+				comments = append(comments, text)
+			}
+			continue
+		case strings.HasPrefix(trimmed, "["):
+			table = strings.Trim(trimmed, "[] ")
+			if len(comments) > 0 {
+				result[table] = strings.Join(comments, " ")
+			}
+		case len(trimmed) > 0:
+			if key, _, ok := strings.Cut(trimmed, "="); ok && len(comments) > 0 {
+				key = strings.TrimSpace(key)
+				if len(table) > 0 {
+					key = table + "." + key
+				}
+				result[key] = strings.Join(comments, " ")
+			}
+		}
+		comments = nil
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SMS providers supported by GoTrue, in the order listed by the init config template.
+var smsProviders = []string{"twilio", "twilio_verify", "messagebird", "textlocal", "vonage"}
+
+type templateField struct {
+	Key     string
+	Comment string
+	// Literal TOML value, ignored if Secret is set
+	Value  string
+	Secret bool
+}
+
+// Returns a commented TOML snippet for a config table, such as auth.external.github, that can be
+// pasted into config.toml. Secrets are set to env() placeholders. Returns an empty string if the
+// table is not supported.
+func GenerateProviderTemplate(provider string) string {
+	table := strings.TrimSpace(provider)
+	parts := strings.SplitN(table, ".", 3)
+	var fields []templateField
+	switch {
+	case len(parts) == 3 && parts[0] == "auth" && parts[1] == "external" && providerNamePattern.MatchString(parts[2]):
+		fields = oauthTemplateFields(parts[2])
+	case len(parts) == 3 && parts[0] == "auth" && parts[1] == "sms" && SliceContains(smsProviders, parts[2]):
+		fields = smsTemplateFields(parts[2])
+	case table == "storage.s3":
+		fields = []templateField{
+			{Key: "endpoint", Value: `""`, Comment: "Leave empty to use AWS S3, or set to the URL of an S3-compatible service such as MinIO."},
+			{Key: "region", Value: `"us-east-1"`, Comment: "Region of the bucket."},
+			{Key: "bucket", Value: `""`, Comment: "Name of an existing bucket that stores all objects."},
+			{Key: "access_key", Secret: true, Comment: "Access key ID with read and write access to the bucket."},
+			{Key: "secret_key", Secret: true, Comment: "Secret access key paired with access_key."},
+		}
+	case len(parts) == 2 && parts[0] == "functions" && FuncSlugPattern.MatchString(parts[1]):
+		fields = []templateField{
+			{Key: "verify_jwt", Value: "true", Comment: "Reject requests without a valid JWT in the Authorization header."},
+			{Key: "entrypoint", Value: `"./index.ts"`, Comment: "Path relative to the function directory."},
+			{Key: "import_map", Value: `""`, Comment: "Overrides the import map shared by all functions."},
+			{Key: "timeout", Value: `"150s"`, Comment: "Wall clock limit of each request in milliseconds or as a duration, between 100ms and 5m."},
+			{Key: "memory", Value: `"150MB"`, Comment: "Memory limit of each worker, up to 1GB."},
+		}
+	default:
+		return ""
+	}
+	var out strings.Builder
+	if table == "storage.s3" {
+		fmt.Fprintln(&out, "[storage]")
+		fmt.Fprintln(&out, "# Store objects in the S3 bucket configured below instead of the local volume.")
+		fmt.Fprintf(&out, "backend = %q\n\n", StorageBackendS3)
+	}
+	fmt.Fprintf(&out, "[%s]\n", table)
+	for _, f := range fields {
+		fmt.Fprintf(&out, "# %s\n", f.Comment)
+		value := f.Value
+		if f.Secret {
+			value = fmt.Sprintf(`"env(%s)"`, secretEnvName(table+"."+f.Key))
+		}
+		fmt.Fprintf(&out, "%s = %s\n", f.Key, value)
+	}
+	return out.String()
+}
+
+// Returns examples of the tables accepted by GenerateProviderTemplate.
+func ProviderTemplateKeys() []string {
+	var result []string
+	for _, name := range externalProviders {
+		result = append(result, "auth.external."+name)
+	}
+	for _, name := range smsProviders {
+		result = append(result, "auth.sms."+name)
+	}
+	sort.Strings(result)
+	return append(result, "storage.s3", "functions.<name>")
+}
+
+func oauthTemplateFields(name string) []templateField {
+	fields := []templateField{
+		{Key: "enabled", Value: "true", Comment: "Allow users to sign in with this provider."},
+		{Key: "client_id", Secret: true, Comment: "OAuth client ID issued by the provider."},
+		{Key: "secret", Secret: true, Comment: "OAuth client secret. DO NOT commit it to git, set the variable in supabase/.env instead."},
+		{Key: "redirect_uri", Value: `""`, Comment: "Overrides the default auth redirectUrl."},
+	}
+	if _, ok := tenantProviders[name]; ok {
+		fields = append(fields, templateField{Key: "url", Value: `""`, Comment: "Base URL of your self-hosted or single-tenant instance. Must use https."})
+	}
+	if name == "apple" {
+		fields = append(fields,
+			templateField{Key: "team_id", Secret: true, Comment: "Team ID of your Apple developer account."},
+			templateField{Key: "key_id", Secret: true, Comment: "ID of the Sign in with Apple private key."},
+		)
+	}
+	if !isKnownProvider(name) {
+		fields = append(fields, templateField{Key: "url", Value: `""`, Comment: fmt.Sprintf("Passed to GoTrue as %s_URL without validation.", providerEnvPrefix(name))})
+	}
+	return fields
+}
+
+func smsTemplateFields(name string) []templateField {
+	fields := []templateField{
+		{Key: "enabled", Value: "true", Comment: "Send OTPs with this provider. Only one SMS provider may be enabled."},
+	}
+	switch name {
+	case "twilio", "twilio_verify":
+		fields = append(fields,
+			templateField{Key: "account_sid", Value: `""`, Comment: "Account SID from the Twilio console."},
+			templateField{Key: "message_service_sid", Value: `""`, Comment: "SID of the messaging service that sends OTPs."},
+			templateField{Key: "auth_token", Secret: true, Comment: "Auth token from the Twilio console."},
+		)
+	case "messagebird":
+		fields = append(fields,
+			templateField{Key: "originator", Value: `""`, Comment: "Sender name or phone number shown to recipients."},
+			templateField{Key: "access_key", Secret: true, Comment: "Live access key from the MessageBird dashboard."},
+		)
+	case "textlocal":
+		fields = append(fields,
+			templateField{Key: "sender", Value: `""`, Comment: "Sender name shown to recipients."},
+			templateField{Key: "api_key", Secret: true, Comment: "API key from the Textlocal dashboard."},
+		)
+	case "vonage":
+		fields = append(fields,
+			templateField{Key: "from", Value: `""`, Comment: "Sender name or phone number shown to recipients."},
+			templateField{Key: "api_key", Secret: true, Comment: "API key from the Vonage dashboard."},
+			templateField{Key: "api_secret", Secret: true, Comment: "API secret paired with api_key."},
+		)
+	}
+	return fields
+}
@@ -1,13 +1,25 @@
 package utils
 
 import (
+	"bytes"
+	"context"
 	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"text/template"
 
+	"filippo.io/age"
 	"github.com/BurntSushi/toml"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/spf13/afero"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -26,7 +38,7 @@ func TestConfigParsing(t *testing.T) {
 	t.Run("classic config file", func(t *testing.T) {
 		defer teardown()
 		fsys := afero.NewMemMapFs()
-		assert.NoError(t, WriteConfig(fsys, false))
+		assert.NoError(t, InitConfig(InitParams{}, fsys))
 		assert.NoError(t, LoadConfigFS(fsys))
 	})
 
@@ -36,7 +48,7 @@ func TestConfigParsing(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		assert.NoError(t, afero.WriteFile(fsys, "supabase/templates/invite.html", nil, 0644))
-		assert.NoError(t, WriteConfig(fsys, true))
+		assert.NoError(t, InitConfig(InitParams{}, fsys))
 		// Run test
 		t.Setenv("TWILIO_AUTH_TOKEN", "token")
 		t.Setenv("AZURE_CLIENT_ID", "hello")
@@ -52,12 +64,3040 @@ func TestConfigParsing(t *testing.T) {
 		initConfigTemplate = testInitConfigTemplate
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
-		assert.NoError(t, WriteConfig(fsys, true))
+		assert.NoError(t, InitConfig(InitParams{}, fsys))
 		// Run test
 		assert.Error(t, LoadConfigFS(fsys))
 	})
 }
 
+func TestExternalProviderValidation(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+	}
+	teardown()
+
+	t.Run("throws error on missing redirect uri scheme", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[auth.external.github]
+enabled = true
+client_id = "hello"
+secret = "env(TEST_SECRET:-world)"
+redirect_uri = "localhost:54321/auth/v1/callback"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "auth.external.github.redirect_uri")
+	})
+
+	t.Run("throws error on missing url host", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[auth.external.github]
+enabled = true
+client_id = "hello"
+secret = "env(TEST_SECRET:-world)"
+url = "https://"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "auth.external.github.url")
+	})
+
+	t.Run("accepts valid provider urls", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[auth.external.github]
+enabled = true
+client_id = "hello"
+secret = "env(TEST_SECRET:-world)"
+redirect_uri = "http://localhost:54321/auth/v1/callback"
+url = "https://github.example.com"
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+	})
+
+	t.Run("throws error on insecure tenant url", func(t *testing.T) {
+		defer teardown()
+		t.Setenv("AZURE_TENANT_URL", "http://login.microsoftonline.com/tenant")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[auth.external.azure]
+enabled = true
+client_id = "hello"
+secret = "env(TEST_SECRET:-world)"
+url = "env(AZURE_TENANT_URL)"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "auth.external.azure.url")
+		assert.ErrorContains(t, err, "must use https")
+	})
+
+	t.Run("parses skip nonce check", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[auth.external.apple]
+enabled = true
+client_id = "hello"
+secret = "env(TEST_SECRET:-world)"
+skip_nonce_check = true
+team_id = "team"
+key_id = "key"
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.True(t, Config.Auth.External["apple"].SkipNonceCheck)
+	})
+
+	t.Run("accepts pkce flow type", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[auth.external.github]
+enabled = true
+client_id = "hello"
+secret = "env(TEST_SECRET:-world)"
+flow_type = "pkce"
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, "pkce", Config.Auth.External["github"].FlowType)
+	})
+
+	t.Run("throws error on invalid flow type", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[auth.external.github]
+enabled = true
+client_id = "hello"
+secret = "env(TEST_SECRET:-world)"
+flow_type = "hybrid"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for auth.external.github.flow_type")
+	})
+}
+
+func TestRedirectUrlValidation(t *testing.T) {
+	t.Run("accepts wildcard host", func(t *testing.T) {
+		assert.NoError(t, validateRedirectUrl("https://*.example.com"))
+		assert.NoError(t, validateRedirectUrl("http://localhost:3000/callback"))
+	})
+
+	t.Run("throws error on missing scheme", func(t *testing.T) {
+		err := validateRedirectUrl("localhost:3000")
+		assert.ErrorContains(t, err, `"localhost:3000" must be an absolute URL`)
+	})
+
+	t.Run("throws error on malformed site url", func(t *testing.T) {
+		err := validateAbsoluteUrl("http//localhost:3000")
+		assert.ErrorContains(t, err, `"http//localhost:3000" must be an absolute URL`)
+	})
+
+	// Reset global variable
+	siteUrl := Config.Auth.SiteUrl
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Auth.SiteUrl = siteUrl
+		Config.Auth.AdditionalRedirectUrls = nil
+	}
+
+	t.Run("loads urls from env", func(t *testing.T) {
+		defer teardown()
+		t.Setenv("SITE_URL", "http://localhost:3000")
+		t.Setenv("PREVIEW_URL", "https://*.vercel.app/**")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth]
+site_url = "env(SITE_URL)"
+additional_redirect_urls = ["https://127.0.0.1:3000", "env(PREVIEW_URL)"]
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check resolved values
+		assert.Equal(t, "http://localhost:3000", Config.Auth.SiteUrl)
+		assert.Equal(t, []string{"https://127.0.0.1:3000", "https://*.vercel.app/**"}, Config.Auth.AdditionalRedirectUrls)
+	})
+
+	t.Run("throws error with index of invalid redirect url", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth]
+additional_redirect_urls = ["https://127.0.0.1:3000", "http//localhost:3000"]
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, `Invalid config for auth.additional_redirect_urls[1]: "http//localhost:3000" must be an absolute URL`)
+	})
+}
+
+func TestSmsTestOTPValidation(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Auth.Sms.TestOTP = nil
+	}
+	teardown()
+
+	t.Run("accepts test otp without sms provider", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[auth.sms.test_otp]
+"+14152127777" = "123456"
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, "123456", Config.Auth.Sms.TestOTP["+14152127777"])
+		assert.False(t, Config.Auth.Sms.Twilio.Enabled)
+	})
+
+	t.Run("throws error on invalid phone number", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[auth.sms.test_otp]
+"415-212-7777" = "123456"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "E.164")
+	})
+
+	t.Run("throws error on invalid otp", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[auth.sms.test_otp]
+4152127777 = "1234"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "OTP must be 6 digits")
+	})
+}
+
+func TestNewExternalProviders(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+	}
+	teardown()
+
+	for _, name := range []string{"kakao", "figma", "fly", "linkedin_oidc"} {
+		t.Run("resolves env credentials for "+name, func(t *testing.T) {
+			defer teardown()
+			upper := strings.ToUpper(name)
+			t.Setenv(upper+"_CLIENT_ID", "hello")
+			t.Setenv(upper+"_SECRET", "world")
+			// Setup in-memory fs
+			fsys := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[auth.external.`+name+`]
+enabled = true
+client_id = "env(`+upper+`_CLIENT_ID)"
+secret = "env(`+upper+`_SECRET)"
+`), 0644))
+			// Run test
+			assert.NoError(t, LoadConfigFS(fsys))
+			// Check config
+			assert.Equal(t, "hello", Config.Auth.External[name].ClientId)
+			assert.Equal(t, "world", Config.Auth.External[name].Secret)
+			assert.Contains(t, Config.EnabledExternalProviders(), name)
+		})
+	}
+}
+
+func TestAppleProviderConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+	}
+	teardown()
+
+	t.Run("resolves team and key id", func(t *testing.T) {
+		defer teardown()
+		t.Setenv("APPLE_KEY_ID", "ABC123DEFG")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[auth.external.apple]
+enabled = true
+client_id = "hello"
+secret = "env(TEST_SECRET:-world)"
+team_id = "DEF123GHIJ"
+key_id = "env(APPLE_KEY_ID)"
+`), 0644))
+		// Run test
+		viper.Set("STRICT-CONFIG", true)
+		defer viper.Set("STRICT-CONFIG", false)
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, "DEF123GHIJ", Config.Auth.Apple.TeamId)
+		assert.Equal(t, "ABC123DEFG", Config.Auth.Apple.KeyId)
+		assert.Equal(t, "hello", Config.Auth.Apple.ClientId)
+	})
+
+	t.Run("throws error on missing team id", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[auth.external.apple]
+enabled = true
+client_id = "hello"
+secret = "env(TEST_SECRET:-world)"
+key_id = "ABC123DEFG"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Missing required field in config: auth.external.apple.team_id")
+	})
+
+	t.Run("ignores missing ids when disabled", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[auth.external.apple]
+enabled = false
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+	})
+}
+
+func TestSignAuthKey(t *testing.T) {
+	t.Run("reproduces demo keys", func(t *testing.T) {
+		anonKey, err := SignAuthKey(defaultJwtSecret, "anon")
+		assert.NoError(t, err)
+		assert.Equal(t, defaultAnonKey, anonKey)
+		serviceRoleKey, err := SignAuthKey(defaultJwtSecret, "service_role")
+		assert.NoError(t, err)
+		assert.Equal(t, defaultServiceRoleKey, serviceRoleKey)
+	})
+}
+
+func TestShadowPortConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+	}
+	teardown()
+
+	for _, c := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{"accepts distinct port", `port = 54322
+shadow_port = 54320`, ""},
+		{"throws error on same port", `port = 54322
+shadow_port = 54322`, "Invalid config for db.shadow_port: must differ from db.port, got 54322"},
+		{"throws error on privileged port", `shadow_port = 543`, "Invalid config for db.shadow_port: must be at least 1024 to avoid privileged ports, got 543"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			defer teardown()
+			// Setup in-memory fs
+			fsys := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[db]
+`+c.config), 0644))
+			// Run test
+			err := LoadConfigFS(fsys)
+			// Check error
+			if len(c.err) > 0 {
+				assert.ErrorContains(t, err, c.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestApiSchemasConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+	}
+	teardown()
+
+	for _, c := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{"accepts glob patterns", `schemas = ["app_*", "tenant_?"]
+extra_search_path = ["ext_[ab]"]`, ""},
+		{"throws error on invalid identifier", `schemas = ["my-schema"]`, `Invalid config for api.schemas: "my-schema" is not a valid PostgreSQL identifier`},
+		{"throws error on long identifier", `schemas = ["` + strings.Repeat("a", 64) + `"]`, "is not a valid PostgreSQL identifier"},
+		{"throws error on invalid pattern", `extra_search_path = ["app_[*"]`, `Invalid config for api.extra_search_path: "app_[*" is not a valid glob pattern`},
+		{"accepts quoted identifier", `schemas = ["\"My-Schema\""]`, ""},
+		{"throws error on trailing comma", `extra_search_path = ["extensions,"]`, `Invalid config for api.extra_search_path: "extensions," is not a valid PostgreSQL identifier`},
+		{"throws error on unbalanced quotes", `schemas = ["\"app"]`, `Invalid config for api.schemas: "\"app" is not a valid PostgreSQL identifier`},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			defer teardown()
+			// Setup in-memory fs
+			fsys := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[api]
+`+c.config), 0644))
+			// Run test
+			err := LoadConfigFS(fsys)
+			// Check error
+			if len(c.err) > 0 {
+				assert.ErrorContains(t, err, c.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInjectDefaultSchemas(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+	}
+	teardown()
+
+	t.Run("appends missing default schemas", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[api]
+schemas = ["app", "public", "app"]`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, []string{"app", "public", "storage"}, Config.Api.Schemas)
+	})
+
+	t.Run("preserves user ordering", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[api]
+schemas = ["myapp", "public"]
+extra_search_path = ["extensions"]`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, []string{"myapp", "public", "storage"}, Config.Api.Schemas)
+		assert.Equal(t, []string{"extensions", "public"}, Config.Api.ExtraSearchPath)
+	})
+
+	t.Run("unquotes quoted identifiers", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[api]
+schemas = ['"My-Schema"', '"public"']`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, []string{"My-Schema", "public", "storage"}, Config.Api.Schemas)
+	})
+
+	t.Run("skips default schemas when disabled", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[api]
+inject_default_schemas = false
+schemas = ["app", "app"]`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, []string{"app"}, Config.Api.Schemas)
+	})
+}
+
+func TestEdgeRuntimeConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+	}
+	teardown()
+
+	t.Run("defaults policy to oneshot", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[edge_runtime]
+policy = ""
+port = 54326`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.True(t, Config.EdgeRuntime.Enabled)
+		assert.Equal(t, uint(54326), Config.EdgeRuntime.Port)
+		assert.Equal(t, PolicyOneshot, Config.EdgeRuntime.Policy)
+	})
+
+	t.Run("throws error on invalid policy", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[edge_runtime]
+policy = "per_request"`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for edge_runtime.policy: must be one of: [oneshot per_worker]")
+	})
+}
+
+func TestLoggingConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+	}
+	teardown()
+
+	t.Run("throws error on invalid level", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[logging]
+level = "trace"`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for logging.level: must be one of: [debug info warn error]")
+	})
+
+	t.Run("throws error on invalid format", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[logging]
+format = "yaml"`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for logging.format: must be one of: [text json]")
+	})
+
+	t.Run("writes json warnings to file", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[logging]
+format = "json"
+output = "cli.log"`), 0644))
+		// Run test
+		require.NoError(t, LoadConfigFS(fsys))
+		defer CloseLogger()
+		Warnf("disk is %s", "full")
+		// Check output
+		contents, err := afero.ReadFile(fsys, "cli.log")
+		assert.NoError(t, err)
+		var entry map[string]string
+		require.NoError(t, json.Unmarshal(contents, &entry))
+		assert.Equal(t, "warn", entry["level"])
+		assert.Equal(t, "disk is full", entry["msg"])
+	})
+
+	t.Run("hides warnings at error level", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[db]
+seed_paths = ["supabase/seed.sql"]
+[logging]
+level = "error"
+output = "cli.log"`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "supabase/seed.sql", []byte{}, 0644))
+		// Run test
+		require.NoError(t, LoadConfigFS(fsys))
+		defer CloseLogger()
+		Warnf("disk is full")
+		// Check output
+		contents, err := afero.ReadFile(fsys, "cli.log")
+		assert.NoError(t, err)
+		assert.Empty(t, contents)
+	})
+
+	t.Run("applies logging to warnings raised on load", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[db]
+seed_paths = ["supabase/seed.sql"]
+[logging]
+format = "json"
+output = "cli.log"`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "supabase/seed.sql", []byte{}, 0644))
+		// Run test
+		require.NoError(t, LoadConfigFS(fsys))
+		CloseLogger()
+		// Check output
+		contents, err := afero.ReadFile(fsys, "cli.log")
+		assert.NoError(t, err)
+		var entry map[string]string
+		require.NoError(t, json.Unmarshal(contents, &entry))
+		assert.Equal(t, "warn", entry["level"])
+		assert.Contains(t, entry["msg"], "db.seed_paths is deprecated")
+	})
+
+	t.Run("wraps stderr in json", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[logging]
+format = "json"
+output = "cli.log"`), 0644))
+		// Run test
+		require.NoError(t, LoadConfigFS(fsys))
+		fmt.Fprintln(os.Stderr, "Started", Aqua("supabase"))
+		CloseLogger()
+		// Check output
+		contents, err := afero.ReadFile(fsys, "cli.log")
+		assert.NoError(t, err)
+		var entry map[string]string
+		require.NoError(t, json.Unmarshal(contents, &entry))
+		assert.Equal(t, "info", entry["level"])
+		assert.Equal(t, "Started supabase", entry["msg"])
+	})
+
+	t.Run("enables debug output without setting the debug flag", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[logging]
+level = "debug"`), 0644))
+		// Run test
+		require.NoError(t, LoadConfigFS(fsys))
+		defer CloseLogger()
+		// Check output
+		assert.True(t, DebugEnabled())
+		assert.False(t, viper.GetBool("DEBUG"))
+	})
+
+	t.Run("keeps log file open on reload", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[logging]
+output = "cli.log"`), 0644))
+		require.NoError(t, LoadConfigFS(fsys))
+		defer CloseLogger()
+		file := logger.file
+		// Run test
+		require.NoError(t, LoadConfigFS(fsys))
+		// Check output
+		assert.Same(t, file, logger.file)
+	})
+}
+
+func TestConfigError(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+	}
+	teardown()
+
+	for _, c := range []struct {
+		name   string
+		config string
+		key    string
+		value  string
+		reason string
+	}{
+		{"reports missing field", `[api]
+port = 0`, "api.port", "", ""},
+		{"reports invalid field", `[auth]
+jwt_expiry = 0`, "auth.jwt_expiry", "", "must be between 1 and 604800 seconds, got 0"},
+		{"reports nested field", `[auth.external.github]
+enabled = true
+secret = "env(TEST_SECRET:-test)"`, "auth.external.github.client_id", "", ""},
+		{"reports invalid value", `[logging]
+level = "verbose"`, "logging.level", "verbose", "must be one of: [debug info warn error]"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			defer teardown()
+			// Setup in-memory fs
+			fsys := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+`+c.config), 0644))
+			// Run test
+			err := LoadConfigFS(fsys)
+			// Check error
+			var configErr *ConfigError
+			require.ErrorAs(t, err, &configErr)
+			assert.Equal(t, c.key, configErr.Key)
+			assert.Equal(t, c.value, configErr.Value)
+			assert.Equal(t, c.reason, configErr.Reason)
+		})
+	}
+}
+
+func TestConfigExists(t *testing.T) {
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	assert.False(t, ConfigExists(fsys))
+	require.NoError(t, InitConfig(InitParams{ProjectId: "test"}, fsys))
+	// Run test
+	assert.True(t, ConfigExists(fsys))
+}
+
+func TestInitConfig(t *testing.T) {
+	t.Run("throws error on existing config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, InitConfig(InitParams{ProjectId: "test"}, fsys))
+		// Run test
+		err := InitConfig(InitParams{ProjectId: "test"}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "already exists")
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(string(contents), `project_id = "test"`))
+	})
+
+	t.Run("throws error on existing config before fetching remote settings", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, InitConfig(InitParams{ProjectId: "test"}, fsys))
+		// Run test
+		err := InitRemoteConfig(context.Background(), "abcdefghijklmnopqrst", InitParams{}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "already exists")
+	})
+
+	t.Run("overwrites existing config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, InitConfig(InitParams{ProjectId: "first"}, fsys))
+		// Run test
+		assert.NoError(t, InitConfig(InitParams{ProjectId: "second", Overwrite: true}, fsys))
+		// Check config
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(string(contents), "\nproject_id = "))
+		assert.Contains(t, string(contents), `project_id = "second"`)
+		assert.NoError(t, LoadConfigFS(fsys))
+	})
+
+	t.Run("renders major version", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.NoError(t, InitConfig(InitParams{ProjectId: "test", DbMajorVersion: 14}, fsys))
+		// Check config
+		require.NoError(t, LoadConfigFS(fsys))
+		assert.Equal(t, uint(14), Config.Db.MajorVersion)
+		assert.Equal(t, Pg14Image, DbImage)
+	})
+
+	t.Run("throws error on unsupported major version", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := InitConfig(InitParams{ProjectId: "test", DbMajorVersion: 12}, fsys)
+		// Check error
+		assert.EqualError(t, err, "Invalid config for db.major_version: must be one of: [13 14 15], got 12")
+		exists, err := afero.Exists(fsys, ConfigPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("renders port overrides", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.NoError(t, InitConfig(InitParams{
+			ProjectId:     "test",
+			ApiPort:       64321,
+			ShadowPort:    64320,
+			PoolerPort:    64329,
+			InbucketPort:  64324,
+			AnalyticsPort: 64327,
+			VectorPort:    64328,
+		}, fsys))
+		// Check config
+		require.NoError(t, LoadConfigFS(fsys))
+		assert.Equal(t, uint(64321), Config.Api.Port)
+		assert.Equal(t, uint(54322), Config.Db.Port)
+		assert.Equal(t, uint(64320), Config.Db.ShadowPort)
+		assert.Equal(t, uint16(64329), Config.Db.Pooler.Port)
+		assert.Equal(t, uint(64324), Config.Inbucket.Port)
+		assert.Equal(t, uint16(64327), Config.Analytics.Port)
+		assert.Equal(t, uint16(64328), Config.Analytics.VectorPort)
+	})
+}
+
+func TestEncodeToml(t *testing.T) {
+	t.Run("replaces secrets with env placeholders", func(t *testing.T) {
+		c := newDefaultConfig()
+		require.NoError(t, toml.Unmarshal([]byte(initConfigDefaults), &c))
+		c.Auth.External["github"] = provider{Enabled: true, ClientId: "id", Secret: "literal"}
+		c.Auth.Sms.Twilio.AuthToken = "env(TWILIO_TOKEN)"
+		c.Auth.Apple.TeamId = "team"
+		// Run test
+		encoded, envNames, err := c.encodeToml()
+		// Check error
+		assert.NoError(t, err)
+		assert.NotContains(t, encoded, "literal")
+		assert.Contains(t, encoded, `secret = "env(SUPABASE_AUTH_EXTERNAL_GITHUB_SECRET)"`)
+		assert.Contains(t, encoded, `auth_token = "env(TWILIO_TOKEN)"`)
+		assert.Contains(t, encoded, `team_id = "team"`)
+		assert.Contains(t, envNames, "SUPABASE_AUTH_EXTERNAL_GITHUB_SECRET")
+		assert.Contains(t, envNames, "TWILIO_TOKEN")
+		// Validate round trip
+		var decoded config
+		_, err = toml.Decode(encoded, &decoded)
+		assert.NoError(t, err)
+		assert.Equal(t, c.Api, decoded.Api)
+		assert.Equal(t, c.Db.MajorVersion, decoded.Db.MajorVersion)
+	})
+}
+
+func TestWriteConfig(t *testing.T) {
+	t.Run("round trips default config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, InitConfig(InitParams{ProjectId: "test"}, fsys))
+		require.NoError(t, LoadConfigFS(fsys))
+		loaded := Config
+		// Run test
+		assert.NoError(t, WriteConfig(fsys))
+		// Check config
+		require.NoError(t, LoadConfigFS(fsys))
+		assert.Equal(t, loaded, Config)
+	})
+
+	t.Run("round trips custom config", func(t *testing.T) {
+		// Secrets are written with their resolved values
+		viper.Set("ALLOW-PLAINTEXT-SECRETS", true)
+		defer viper.Set("ALLOW-PLAINTEXT-SECRETS", false)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[storage]
+file_size_limit = "5MB"
+[auth.email]
+max_frequency = "90s"
+[auth.sms]
+max_frequency = "1h"
+[auth.external.apple]
+enabled = true
+client_id = "com.example.app"
+secret = "literal"
+team_id = "team"
+key_id = "key"
+[functions.hello]
+verify_jwt = false
+memory = "256MB"
+`), 0644))
+		require.NoError(t, LoadConfigFS(fsys))
+		loaded := Config
+		// Run test
+		assert.NoError(t, WriteConfig(fsys))
+		// Check config
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), `file_size_limit = "5MiB"`)
+		assert.Contains(t, string(contents), `max_frequency = "1m30s"`)
+		assert.Contains(t, string(contents), `max_frequency = "1h"`)
+		assert.NotContains(t, string(contents), defaultJwtSecret)
+		require.NoError(t, LoadConfigFS(fsys))
+		assert.Equal(t, loaded, Config)
+	})
+
+	t.Run("round trips env references", func(t *testing.T) {
+		t.Setenv("GITHUB_SECRET", "resolved-github-secret")
+		t.Setenv("SMTP_PASS", "resolved-smtp-pass")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.email.smtp]
+host = "smtp.example.com"
+port = 587
+user = "admin"
+pass = "env(SMTP_PASS)"
+admin_email = "admin@example.com"
+sender_name = "Admin"
+[auth.external.github]
+enabled = true
+client_id = "client"
+secret = "env(GITHUB_SECRET)"
+`), 0644))
+		require.NoError(t, LoadConfigFS(fsys))
+		loaded := Config
+		// Run test
+		assert.NoError(t, WriteConfig(fsys))
+		// Check config
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), `pass = "env(SMTP_PASS)"`)
+		assert.Contains(t, string(contents), `secret = "env(GITHUB_SECRET)"`)
+		assert.NotContains(t, string(contents), "resolved-")
+		require.NoError(t, LoadConfigFS(fsys))
+		assert.Equal(t, loaded, Config)
+	})
+
+	t.Run("writes placeholders for secrets set in memory", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"`), 0644))
+		require.NoError(t, LoadConfigFS(fsys))
+		Config.Storage.S3.SecretKey = "resolved-s3-secret"
+		// Run test
+		assert.NoError(t, WriteConfig(fsys))
+		// Check config
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), `secret_key = "env(SUPABASE_STORAGE_S3_SECRET_KEY)"`)
+		assert.NotContains(t, string(contents), "resolved-")
+	})
+}
+
+func TestCustomConfigPath(t *testing.T) {
+	defer func(path string) {
+		ConfigPath = path
+		CmdSuggestion = ""
+	}(ConfigPath)
+	ConfigPath = filepath.Join(SupabaseDirPath, "config.test.toml")
+
+	t.Run("loads alternate config file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "integration"`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, "integration", Config.ProjectId)
+	})
+
+	t.Run("suggests checking the attempted path", func(t *testing.T) {
+		// Run test
+		err := LoadConfigFS(afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "config.test.toml")
+		assert.Contains(t, CmdSuggestion, "SUPABASE_CONFIG_PATH")
+		assert.Contains(t, CmdSuggestion, "config.test.toml")
+	})
+}
+
+func TestRepeatedLoads(t *testing.T) {
+	t.Run("does not leak state between loads", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "first"
+[auth.external.github]
+enabled = true
+client_id = "test-client"
+secret = "env(TEST_SECRET:-test-secret)"
+[auth.sms.test_otp]
+4152127777 = "123456"
+[functions.hello]
+verify_jwt = false
+`), 0644))
+		require.NoError(t, LoadConfigFS(fsys))
+		require.Equal(t, "test-client", Config.Auth.External["github"].ClientId)
+		// Run test
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "second"`), 0644))
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, "second", Config.ProjectId)
+		assert.Equal(t, provider{}, Config.Auth.External["github"])
+		assert.Empty(t, Config.Auth.Sms.TestOTP)
+		assert.Empty(t, Config.Functions)
+	})
+}
+
+func TestDeriveAuthKeys(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Auth.JwtSecret = defaultJwtSecret
+		Config.Auth.AnonKey = defaultAnonKey
+		Config.Auth.ServiceRoleKey = defaultServiceRoleKey
+		viper.Reset()
+	}
+	teardown()
+
+	t.Run("derives keys from custom jwt secret", func(t *testing.T) {
+		defer teardown()
+		secret := "my-custom-jwt-secret-with-at-least-32-characters"
+		viper.Set("auth.jwt_secret", secret)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		for role, key := range map[string]string{
+			"anon":         Config.Auth.AnonKey,
+			"service_role": Config.Auth.ServiceRoleKey,
+		} {
+			var claims authKeyClaims
+			_, err := jwt.ParseWithClaims(key, &claims, func(*jwt.Token) (any, error) {
+				return []byte(secret), nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, role, claims.Role)
+		}
+	})
+
+	t.Run("keeps custom keys", func(t *testing.T) {
+		defer teardown()
+		secret := "my-custom-jwt-secret-with-at-least-32-characters"
+		customKey, err := jwt.NewWithClaims(jwt.SigningMethodHS256, authKeyClaims{
+			Issuer: "custom",
+			Role:   "anon",
+		}).SignedString([]byte(secret))
+		require.NoError(t, err)
+		viper.Set("auth.jwt_secret", secret)
+		viper.Set("auth.anon_key", customKey)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, customKey, Config.Auth.AnonKey)
+		assert.NotEqual(t, defaultServiceRoleKey, Config.Auth.ServiceRoleKey)
+	})
+
+	t.Run("keeps demo keys with default secret", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, defaultAnonKey, Config.Auth.AnonKey)
+		assert.Equal(t, defaultServiceRoleKey, Config.Auth.ServiceRoleKey)
+	})
+}
+
+func TestVerifyAuthKeys(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Auth.AnonKey = defaultAnonKey
+		Config.Auth.ServiceRoleKey = defaultServiceRoleKey
+		viper.Reset()
+	}
+	teardown()
+
+	t.Run("throws error on key signed by another secret", func(t *testing.T) {
+		defer teardown()
+		anonKey, err := SignAuthKey("production-jwt-secret-with-at-least-32-characters", "anon")
+		require.NoError(t, err)
+		viper.Set("auth.anon_key", anonKey)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		err = LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for auth.anon_key: failed to verify against jwt secret")
+		var configErr *ConfigError
+		require.ErrorAs(t, err, &configErr)
+		assert.Contains(t, configErr.Suggestion, "--skip-jwt-verification")
+	})
+
+	t.Run("throws error on mismatched role", func(t *testing.T) {
+		defer teardown()
+		viper.Set("auth.service_role_key", defaultAnonKey)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, `Invalid config for auth.service_role_key: expected role claim "service_role" but found "anon"`)
+	})
+
+	t.Run("skips verification with flag", func(t *testing.T) {
+		defer teardown()
+		viper.Set("auth.anon_key", "not-a-jwt")
+		viper.Set("SKIP-JWT-VERIFICATION", true)
+		defer viper.Set("SKIP-JWT-VERIFICATION", false)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+	})
+}
+
+func TestJwtSecretValidation(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Auth.JwtSecret = defaultJwtSecret
+		viper.Reset()
+	}
+	teardown()
+
+	t.Run("throws error on short jwt secret", func(t *testing.T) {
+		defer teardown()
+		viper.Set("auth.jwt_secret", "too-short")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for auth.jwt_secret: must be at least 32 characters")
+	})
+}
+
+func TestRateLimitingConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Api.RateLimiting = rateLimiting{}
+	}
+	teardown()
+
+	for _, c := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{"accepts valid limits", `requests_per_second = 0.5
+key = "user"`, ""},
+		{"throws error on non-positive rate", `requests_per_second = 0
+key = "ip"`, "Invalid config for api.rate_limiting.requests_per_second: must be greater than 0"},
+		{"throws error on invalid key", `requests_per_second = 10
+key = "consumer"`, "Invalid config for api.rate_limiting.key: must be one of: [ip user service]"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			defer teardown()
+			// Setup in-memory fs
+			fsys := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[api.rate_limiting]
+enabled = true
+`+c.config), 0644))
+			// Run test
+			err := LoadConfigFS(fsys)
+			// Check error
+			if len(c.err) > 0 {
+				assert.ErrorContains(t, err, c.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRealtimeConfig(t *testing.T) {
+	// Reset global variable
+	realtime := Config.Realtime
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Realtime = realtime
+	}
+	teardown()
+
+	for _, c := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{"defaults to current behaviour", ``, ""},
+		{"throws error on missing port", `port = 0`, "Missing required field in config: realtime.port"},
+		{"throws error on too many users", `max_concurrent_users = 10001`, "Invalid config for realtime.max_concurrent_users: must be between 1 and 10000"},
+		{"throws error on zero channels", `max_channels_per_client = 0`, "Invalid config for realtime.max_channels_per_client: must be between 1 and 1000"},
+		{"skips validation when disabled", "enabled = false\nport = 0", ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			defer teardown()
+			// Setup in-memory fs
+			fsys := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[realtime]
+`+c.config), 0644))
+			// Run test
+			err := LoadConfigFS(fsys)
+			// Check error
+			if len(c.err) > 0 {
+				assert.ErrorContains(t, err, c.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+	assert.Equal(t, uint(4000), Config.Realtime.Port)
+}
+
+func TestImageTransformationConfig(t *testing.T) {
+	// Reset global variable
+	storage := Config.Storage
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Storage = storage
+	}
+	teardown()
+
+	t.Run("throws error on zero resolution", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[storage.image_transformation]
+max_resolution = 0
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for storage.image_transformation.max_resolution: must be greater than 0")
+	})
+
+	t.Run("disables imgproxy with storage", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[storage]
+enabled = false
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check resolved value
+		assert.False(t, Config.Storage.ImageTransformation.Enabled)
+	})
+}
+
+func TestFileSizeLimitConfig(t *testing.T) {
+	// Reset global variable
+	storage := Config.Storage
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Storage = storage
+	}
+	teardown()
+
+	t.Run("throws error on zero limit", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[storage]
+file_size_limit = 0
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for storage.file_size_limit: must be greater than 0")
+	})
+
+	t.Run("accepts large limit", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[storage]
+file_size_limit = "500GB"
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check resolved value
+		assert.Equal(t, sizeInBytes(500<<30), Config.Storage.FileSizeLimit)
+	})
+
+	t.Run("skips validation when storage is disabled", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[storage]
+enabled = false
+file_size_limit = 0
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+	})
+}
+
+func TestDisabledServicesConfig(t *testing.T) {
+	// Reset global variable
+	api := Config.Api
+	storage := Config.Storage
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Api = api
+		Config.Storage = storage
+	}
+	teardown()
+
+	t.Run("skips checks on disabled services", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[api]
+enabled = false
+[storage]
+enabled = false
+backend = "gcs"
+file_size_limit = 0
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check resolved value
+		assert.False(t, Config.Api.Enabled)
+		assert.False(t, Config.Storage.Enabled)
+	})
+
+	t.Run("loads disabled api without port", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[api]
+enabled = false
+port = 0
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check resolved value
+		assert.False(t, Config.Api.Enabled)
+		assert.Zero(t, Config.Api.Port)
+	})
+}
+
+func TestJwtAlgorithmConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Auth.JwtAlgorithm = JwtHS256
+		Config.Auth.JwtPublicKey = ""
+		viper.Reset()
+	}
+	teardown()
+
+	t.Run("throws error on unsupported algorithm", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth]
+jwt_algorithm = "HS512"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for auth.jwt_algorithm: must be one of: [HS256 RS256 ES256]")
+	})
+
+	t.Run("throws error on missing public key", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth]
+jwt_algorithm = "RS256"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for auth.jwt_public_key: required when jwt_algorithm is RS256")
+	})
+
+	t.Run("verifies with public key", func(t *testing.T) {
+		defer teardown()
+		viper.Set("auth.jwt_public_key", `{"kty":"EC"}`)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth]
+jwt_algorithm = "ES256"
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check resolved value
+		assert.Equal(t, `{"kty":"EC"}`, Config.Auth.JwtVerificationKey())
+	})
+}
+
+func TestRefreshTokenConfig(t *testing.T) {
+	// Reset global variable
+	auth := Config.Auth
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Auth.JwtExpiry = auth.JwtExpiry
+		Config.Auth.EnableRefreshTokenRotation = auth.EnableRefreshTokenRotation
+		Config.Auth.RefreshTokenReuseInterval = auth.RefreshTokenReuseInterval
+	}
+	teardown()
+
+	for _, c := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{"throws error on zero expiry", `jwt_expiry = 0`, "Invalid config for auth.jwt_expiry: must be between 1 and 604800 seconds, got 0"},
+		{"throws error on long expiry", `jwt_expiry = 604801`, "Invalid config for auth.jwt_expiry: must be between 1 and 604800 seconds, got 604801"},
+		{"throws error on reuse without rotation", `enable_refresh_token_rotation = false
+refresh_token_reuse_interval = 10`, "Invalid config for auth.refresh_token_reuse_interval: 10 requires enable_refresh_token_rotation = true, got false"},
+		{"accepts disabled rotation", `enable_refresh_token_rotation = false
+refresh_token_reuse_interval = 0`, ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			defer teardown()
+			// Setup in-memory fs
+			fsys := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth]
+`+c.config), 0644))
+			// Run test
+			err := LoadConfigFS(fsys)
+			// Check error
+			if len(c.err) > 0 {
+				assert.ErrorContains(t, err, c.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMaxConnectionsConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Db.MaxConnections = 100
+	}
+	teardown()
+
+	for _, value := range []uint{9, 10001} {
+		t.Run(fmt.Sprintf("throws error on %d connections", value), func(t *testing.T) {
+			defer teardown()
+			// Setup in-memory fs
+			fsys := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(fmt.Sprintf(`project_id = "test"
+[db]
+max_connections = %d
+`, value)), 0644))
+			// Run test
+			err := LoadConfigFS(fsys)
+			// Check error
+			assert.ErrorContains(t, err, fmt.Sprintf("Invalid config for db.max_connections: must be between 10 and 10000, got %d", value))
+		})
+	}
+}
+
+func TestStorageBackendConfig(t *testing.T) {
+	// Reset global variable
+	storage := Config.Storage
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Storage = storage
+	}
+	teardown()
+
+	t.Run("loads s3 credentials from env", func(t *testing.T) {
+		defer teardown()
+		t.Setenv("SUPABASE_STORAGE_S3_ACCESS_KEY", "access")
+		t.Setenv("SUPABASE_STORAGE_S3_SECRET_KEY", "secret")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[storage]
+backend = "s3"
+[storage.s3]
+endpoint = "http://minio:9000"
+bucket = "test"
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check resolved values
+		assert.Equal(t, "access", Config.Storage.S3.AccessKey)
+		assert.Equal(t, "secret", Config.Storage.S3.SecretKey)
+	})
+
+	t.Run("throws error on missing bucket", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[storage]
+backend = "s3"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Missing required field in config: storage.s3.bucket")
+	})
+
+	t.Run("throws error on missing credentials", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[storage]
+backend = "s3"
+[storage.s3]
+bucket = "test"
+access_key = ""
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Missing required field in config: storage.s3.access_key")
+	})
+
+	t.Run("throws error on invalid backend", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[storage]
+backend = "gcs"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for storage.backend: must be one of: [file s3]")
+	})
+}
+
+func TestThirdPartyConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Auth.ThirdParty = thirdParty{}
+	}
+	teardown()
+
+	for _, c := range []struct {
+		name   string
+		config string
+		err    string
+		issuer string
+	}{
+		{"resolves firebase issuer", `[auth.third_party.firebase]
+enabled = true
+project_id = "test"`, "", "https://securetoken.google.com/test"},
+		{"resolves auth0 issuer", `[auth.third_party.auth0]
+enabled = true
+tenant = "test"
+tenant_region = "eu"`, "", "https://test.eu.auth0.com/"},
+		{"throws error on missing pool region", `[auth.third_party.aws_cognito]
+enabled = true
+user_pool_id = "test"`, "Missing required field in config: auth.third_party.aws_cognito.user_pool_region", ""},
+		{"throws error on multiple providers", `[auth.third_party.firebase]
+enabled = true
+project_id = "test"
+[auth.third_party.auth0]
+enabled = true
+tenant = "test"`, "Invalid config for auth.third_party: only one provider can be enabled, got [firebase auth0]", ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			defer teardown()
+			// Setup in-memory fs
+			fsys := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte("project_id = \"test\"\n"+c.config), 0644))
+			// Run test
+			err := LoadConfigFS(fsys)
+			// Check error
+			if len(c.err) > 0 {
+				assert.ErrorContains(t, err, c.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, c.issuer, Config.Auth.ThirdParty.IssuerURL())
+			}
+		})
+	}
+}
+
+func TestAuthHookConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Auth.Hook = nil
+	}
+	teardown()
+
+	for _, c := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{"accepts postgres function", `[auth.hook.custom_access_token]
+enabled = true
+uri = "pg-functions://postgres/public/custom_access_token_hook"`, ""},
+		{"loads http secrets from env", `[auth.hook.send_sms]
+enabled = true
+uri = "https://example.com/hook"
+secrets = "env(SEND_SMS_SECRETS)"`, ""},
+		{"accepts plain http uri", `[auth.hook.send_sms]
+enabled = true
+uri = "http://host.docker.internal:8080/hook"
+secrets = "env(SEND_SMS_SECRETS)"`, ""},
+		{"skips disabled hook", `[auth.hook.send_email]
+enabled = false
+uri = "ftp://example.com"`, ""},
+		{"throws error on unknown hook", `[auth.hook.on_signup]
+enabled = true`, "Invalid config for auth.hook.on_signup: must be one of: [custom_access_token send_sms send_email mfa_verification_attempt password_verification_attempt]"},
+		{"throws error on missing function schema", `[auth.hook.custom_access_token]
+enabled = true
+uri = "pg-functions://postgres/custom_access_token_hook"`, "must be in the form pg-functions://<database>/<schema>/<function>"},
+		{"throws error on missing uri", `[auth.hook.send_sms]
+enabled = true`, "Missing required field in config: auth.hook.send_sms.uri"},
+		{"throws error on unsupported scheme", `[auth.hook.send_email]
+enabled = true
+uri = "ftp://example.com"`, "Invalid config for auth.hook.send_email.uri: scheme must be one of: [pg-functions http https]"},
+		{"throws error on missing secrets", `[auth.hook.send_email]
+enabled = true
+uri = "https://example.com/hook"`, "Missing required field in config: auth.hook.send_email.secrets"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			defer teardown()
+			t.Setenv("SEND_SMS_SECRETS", "v1,whsec_test")
+			// Setup in-memory fs
+			fsys := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte("project_id = \"test\"\n"+c.config), 0644))
+			// Run test
+			err := LoadConfigFS(fsys)
+			// Check error
+			if len(c.err) > 0 {
+				assert.ErrorContains(t, err, c.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOtpConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Auth.Email.OtpLength = 0
+		Config.Auth.Email.OtpExpiry = 0
+		Config.Auth.Sms.OtpLength = 0
+		Config.Auth.Sms.OtpExpiry = 0
+	}
+	teardown()
+
+	t.Run("parses expiry as seconds or duration", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.email]
+otp_length = 8
+otp_expiry = 3600
+[auth.sms]
+otp_length = 10
+otp_expiry = "5m"
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check resolved value
+		assert.Equal(t, uint(8), Config.Auth.Email.OtpLength)
+		assert.Equal(t, durationInSeconds(3600), Config.Auth.Email.OtpExpiry)
+		assert.Equal(t, uint(10), Config.Auth.Sms.OtpLength)
+		assert.Equal(t, durationInSeconds(300), Config.Auth.Sms.OtpExpiry)
+	})
+
+	t.Run("throws error on invalid length", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.sms]
+otp_length = 4
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for auth.sms.otp_length: must be between 6 and 10, got 4")
+	})
+
+	t.Run("throws error on invalid expiry", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.email]
+otp_expiry = "-1h"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "duration must not be negative: -1h")
+	})
+}
+
+func TestSmtpConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+	}
+	teardown()
+
+	t.Run("loads password from env", func(t *testing.T) {
+		defer teardown()
+		t.Setenv("SENDGRID_API_KEY", "secret")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.email.smtp]
+host = "smtp.sendgrid.net"
+port = 587
+user = "apikey"
+pass = "env(SENDGRID_API_KEY)"
+admin_email = "admin@email.com"
+sender_name = "Admin"
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check resolved value
+		assert.Equal(t, "smtp.sendgrid.net", Config.Auth.Email.Smtp.Host)
+		assert.Equal(t, uint(587), Config.Auth.Email.Smtp.Port)
+		assert.Equal(t, "secret", Config.Auth.Email.Smtp.Pass)
+	})
+
+	t.Run("throws error on missing host", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.email.smtp]
+port = 587
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Missing required field in config: auth.email.smtp.host")
+	})
+
+	t.Run("throws error on missing port", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.email.smtp]
+host = "smtp.sendgrid.net"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Missing required field in config: auth.email.smtp.port")
+	})
+}
+
+func TestMergePlatformConfig(t *testing.T) {
+	t.Run("merges current platform on top of base", func(t *testing.T) {
+		base := config{ProjectId: "test"}
+		base.Db.Port = 54322
+		base.Db.MaxConnections = 100
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`[platform.`+runtime.GOOS+`.db]
+port = 54422
+[platform.`+otherPlatform()+`.db]
+port = 54522
+`), 0644))
+		// Run test
+		merged, err := MergePlatformConfig(base, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, uint(54422), merged.Db.Port)
+		assert.Equal(t, uint(100), merged.Db.MaxConnections)
+		assert.Equal(t, uint(54322), base.Db.Port)
+	})
+
+	t.Run("returns base without overrides", func(t *testing.T) {
+		base := config{ProjectId: "test"}
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "other"`), 0644))
+		// Run test
+		merged, err := MergePlatformConfig(base, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, base, merged)
+	})
+
+	t.Run("throws error on unknown platform", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`[platform.freebsd.db]
+port = 54422
+`), 0644))
+		// Run test
+		_, err := MergePlatformConfig(config{}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for platform.freebsd: must be one of: [linux darwin windows]")
+	})
+
+	t.Run("throws error on invalid override", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`[platform.`+runtime.GOOS+`.db]
+port = "invalid"
+`), 0644))
+		// Run test
+		_, err := MergePlatformConfig(config{}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for platform."+runtime.GOOS)
+	})
+}
+
+func otherPlatform() string {
+	if runtime.GOOS == "linux" {
+		return "darwin"
+	}
+	return "linux"
+}
+
+func TestAuthEnv(t *testing.T) {
+	t.Run("uses inbucket sender without custom smtp", func(t *testing.T) {
+		var c config
+		c.Inbucket.AdminEmail = "team@example.com"
+		c.Inbucket.SenderName = "Example"
+		// Run test
+		env := c.AuthEnv()
+		// Check mappings
+		assert.Equal(t, "team@example.com", env["GOTRUE_SMTP_ADMIN_EMAIL"])
+		assert.Equal(t, "Example", env["GOTRUE_SMTP_SENDER_NAME"])
+		// Custom smtp takes precedence
+		c.Auth.Email.Smtp = smtp{
+			Host:       "smtp.example.com",
+			Port:       587,
+			User:       "apikey",
+			Pass:       "secret",
+			AdminEmail: "admin@example.com",
+			SenderName: "Custom",
+		}
+		env = c.AuthEnv()
+		assert.Equal(t, "smtp.example.com", env["GOTRUE_SMTP_HOST"])
+		assert.Equal(t, "587", env["GOTRUE_SMTP_PORT"])
+		assert.Equal(t, "apikey", env["GOTRUE_SMTP_USER"])
+		assert.Equal(t, "secret", env["GOTRUE_SMTP_PASS"])
+		assert.Equal(t, "admin@example.com", env["GOTRUE_SMTP_ADMIN_EMAIL"])
+		assert.Equal(t, "Custom", env["GOTRUE_SMTP_SENDER_NAME"])
+	})
+
+	t.Run("maps sms and provider settings", func(t *testing.T) {
+		var c config
+		c.Api.Port = 54321
+		c.Auth.Sms.EnableConfirmations = true
+		c.Auth.Sms.OtpLength = 8
+		c.Auth.Sms.MaxFrequency = 60
+		c.Auth.Sms.TestOTP = map[string]string{"4152127777": "123456"}
+		c.Auth.Sms.Twilio = twilioConfig{
+			Enabled:           true,
+			AccountSid:        "test-sid",
+			MessageServiceSid: "test-service",
+			AuthToken:         "test-token",
+		}
+		c.Auth.External = map[string]provider{
+			"github": {Enabled: true, ClientId: "test-client", Secret: "test-secret"},
+		}
+		// Run test
+		env := c.AuthEnv()
+		// Check mappings
+		assert.Equal(t, "false", env["GOTRUE_SMS_AUTOCONFIRM"])
+		assert.Equal(t, "8", env["GOTRUE_SMS_OTP_LENGTH"])
+		assert.Equal(t, "6000", env["GOTRUE_SMS_OTP_EXP"])
+		assert.Equal(t, "60s", env["GOTRUE_SMS_MAX_FREQUENCY"])
+		assert.Equal(t, `{"4152127777":"123456"}`, env["GOTRUE_SMS_TEST_OTP"])
+		assert.Equal(t, "twilio", env["GOTRUE_SMS_PROVIDER"])
+		assert.Equal(t, "test-token", env["GOTRUE_SMS_TWILIO_AUTH_TOKEN"])
+		assert.Equal(t, "true", env["GOTRUE_EXTERNAL_GITHUB_ENABLED"])
+		assert.Equal(t, "test-client", env["GOTRUE_EXTERNAL_GITHUB_CLIENT_ID"])
+		assert.Equal(t, "http://localhost:54321/auth/v1/callback", env["GOTRUE_EXTERNAL_GITHUB_REDIRECT_URI"])
+		assert.NotContains(t, env, "GOTRUE_EXTERNAL_GITHUB_URL")
+	})
+
+	t.Run("maps smtp to inbucket", func(t *testing.T) {
+		var c config
+		// Run test
+		env := c.AuthEnv()
+		// Check mappings
+		assert.Equal(t, InbucketId, env["GOTRUE_SMTP_HOST"])
+		assert.Equal(t, "2500", env["GOTRUE_SMTP_PORT"])
+		assert.NotContains(t, env, "GOTRUE_SMS_PROVIDER")
+		assert.NotContains(t, env, "GOTRUE_SESSIONS_TIMEBOX")
+	})
+
+	t.Run("maps session limits", func(t *testing.T) {
+		var c config
+		c.Auth.Session = session{TimeboxDuration: 86400, InactivityTimeout: 3600, Tags: []string{"a", "b"}}
+		// Run test
+		env := c.AuthEnv()
+		// Check mappings
+		assert.Equal(t, "86400s", env["GOTRUE_SESSIONS_TIMEBOX"])
+		assert.Equal(t, "3600s", env["GOTRUE_SESSIONS_INACTIVITY_TIMEOUT"])
+		assert.Equal(t, "a,b", env["GOTRUE_SESSIONS_TAGS"])
+	})
+}
+
+func TestMaxFrequencyConfig(t *testing.T) {
+	// Reset global variable
+	email := Config.Auth.Email.MaxFrequency
+	sms := Config.Auth.Sms.MaxFrequency
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Auth.Email.MaxFrequency = email
+		Config.Auth.Sms.MaxFrequency = sms
+	}
+	teardown()
+
+	t.Run("defaults from template", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check resolved value
+		assert.Equal(t, durationInSeconds(1), Config.Auth.Email.MaxFrequency)
+		assert.Equal(t, durationInSeconds(5), Config.Auth.Sms.MaxFrequency)
+	})
+
+	t.Run("parses duration", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.email]
+max_frequency = "1m"
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check resolved value
+		assert.Equal(t, durationInSeconds(60), Config.Auth.Email.MaxFrequency)
+	})
+
+	t.Run("throws error on negative duration", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.sms]
+max_frequency = "-5s"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "duration must not be negative: -5s")
+	})
+}
+
+func TestSessionConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Auth.Session = session{}
+	}
+	teardown()
+
+	for _, c := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{"accepts disabled limits", ``, ""},
+		{"accepts timebox longer than inactivity", `timebox_duration = 86400
+inactivity_timeout = 3600
+tags = ["local"]`, ""},
+		{"throws error on partial limits", `timebox_duration = 86400`, "Invalid config for auth.session: timebox_duration and inactivity_timeout must both be 0 or both be positive, got 86400 and 0"},
+		{"throws error on timebox shorter than inactivity", `timebox_duration = 60
+inactivity_timeout = 3600`, "Invalid config for auth.session.timebox_duration: must be at least inactivity_timeout (3600), got 60"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			defer teardown()
+			// Setup in-memory fs
+			fsys := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.session]
+`+c.config), 0644))
+			// Run test
+			err := LoadConfigFS(fsys)
+			// Check error
+			if len(c.err) > 0 {
+				assert.ErrorContains(t, err, c.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadEncryptedEnv(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	var encrypted bytes.Buffer
+	w, err := age.Encrypt(&encrypted, identity.Recipient())
+	require.NoError(t, err)
+	_, err = w.Write([]byte("TEST_AGE_SECRET=decrypted\nTEST_AGE_OVERRIDE=decrypted\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	t.Run("loads secrets without overriding env", func(t *testing.T) {
+		t.Setenv(AgeIdentityEnv, identity.String())
+		t.Setenv("TEST_AGE_OVERRIDE", "existing")
+		t.Cleanup(func() { os.Unsetenv("TEST_AGE_SECRET") })
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, EncryptedEnvPath, encrypted.Bytes(), 0644))
+		// Run test
+		assert.NoError(t, loadEncryptedEnv(fsys))
+		// Check env
+		assert.Equal(t, "decrypted", os.Getenv("TEST_AGE_SECRET"))
+		assert.Equal(t, "existing", os.Getenv("TEST_AGE_OVERRIDE"))
+	})
+
+	t.Run("throws error on wrong identity", func(t *testing.T) {
+		other, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		t.Setenv(AgeIdentityEnv, other.String())
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, EncryptedEnvPath, encrypted.Bytes(), 0644))
+		// Run test
+		err = loadEncryptedEnv(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to decrypt .env.age")
+	})
+
+	t.Run("skips missing file", func(t *testing.T) {
+		assert.NoError(t, loadEncryptedEnv(afero.NewMemMapFs()))
+	})
+}
+
+func TestMaybeLoadEnv(t *testing.T) {
+	t.Run("returns default when unset", func(t *testing.T) {
+		for ref, expected := range map[string]string{
+			"env(SUPABASE_TEST_UNSET:54321)":       "54321",
+			"env(SUPABASE_TEST_UNSET:-54321)":      "54321",
+			"env(SUPABASE_TEST_UNSET:)":            "",
+			"env(SUPABASE_TEST_UNSET:http://a:1)":  "http://a:1",
+			"env(SUPABASE_TEST_UNSET:-f(x):-(y))":  "f(x):-(y)",
+			"env(SUPABASE_TEST_UNSET:--negative)":  "-negative",
+			"env(SUPABASE_TEST_UNSET: with space)": " with space",
+		} {
+			value, err := maybeLoadEnv(ref)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, value, ref)
+		}
+	})
+
+	t.Run("prefers env over default", func(t *testing.T) {
+		t.Setenv("SUPABASE_TEST_PORT", "1234")
+		value, err := maybeLoadEnv("env(SUPABASE_TEST_PORT:54321)")
+		assert.NoError(t, err)
+		assert.Equal(t, "1234", value)
+	})
+
+	t.Run("uses default when empty", func(t *testing.T) {
+		t.Setenv("SUPABASE_TEST_PORT", "")
+		value, err := maybeLoadEnv("env(SUPABASE_TEST_PORT:-54321)")
+		assert.NoError(t, err)
+		assert.Equal(t, "54321", value)
+	})
+
+	t.Run("throws error without default", func(t *testing.T) {
+		_, err := maybeLoadEnv("env(SUPABASE_TEST_UNSET)")
+		assert.ErrorContains(t, err, "environment variable SUPABASE_TEST_UNSET is unset. Set a default with env(SUPABASE_TEST_UNSET:default)")
+	})
+}
+
+func TestLoadEnvFiles(t *testing.T) {
+	t.Run("later files override earlier ones", func(t *testing.T) {
+		t.Setenv("TEST_ENV_OVERRIDE", "existing")
+		t.Cleanup(func() {
+			os.Unsetenv("TEST_ENV_LOCAL")
+			os.Unsetenv("TEST_ENV_SHARED")
+		})
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "supabase/.env", []byte("TEST_ENV_SHARED=base\nTEST_ENV_OVERRIDE=base\n"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "supabase/.env.local", []byte("TEST_ENV_SHARED=local\nTEST_ENV_LOCAL=local\n"), 0644))
+		// Run test
+		assert.NoError(t, loadEnvFiles(fsys, "supabase/.env", "supabase/.env.local"))
+		// Check env
+		assert.Equal(t, "local", os.Getenv("TEST_ENV_SHARED"))
+		assert.Equal(t, "local", os.Getenv("TEST_ENV_LOCAL"))
+		assert.Equal(t, "existing", os.Getenv("TEST_ENV_OVERRIDE"))
+	})
+
+	t.Run("layers default files with shell winning", func(t *testing.T) {
+		viper.Set("ENV", "staging")
+		defer viper.Set("ENV", "")
+		t.Setenv("TEST_ENV_OVERRIDE", "shell")
+		t.Cleanup(func() {
+			os.Unsetenv("TEST_ENV_BASE")
+			os.Unsetenv("TEST_ENV_SHARED")
+		})
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ".env", []byte("TEST_ENV_BASE=base\nTEST_ENV_SHARED=base\nTEST_ENV_OVERRIDE=base\n"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, ".env.local", []byte("TEST_ENV_SHARED=local\n"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, ".env.staging", []byte("TEST_ENV_SHARED=staging\nTEST_ENV_OVERRIDE=staging\n"), 0644))
+		// Run test
+		assert.NoError(t, loadEnvFiles(fsys))
+		// Check env
+		assert.Equal(t, "base", os.Getenv("TEST_ENV_BASE"))
+		assert.Equal(t, "staging", os.Getenv("TEST_ENV_SHARED"))
+		assert.Equal(t, "shell", os.Getenv("TEST_ENV_OVERRIDE"))
+	})
+
+	t.Run("skips missing default files", func(t *testing.T) {
+		assert.NoError(t, loadEnvFiles(afero.NewMemMapFs()))
+	})
+
+	t.Run("throws error on missing explicit file", func(t *testing.T) {
+		// Run test
+		err := loadEnvFiles(afero.NewMemMapFs(), "supabase/.env.missing")
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("loads config secrets from custom path", func(t *testing.T) {
+		t.Cleanup(func() { os.Unsetenv("TEST_GITHUB_SECRET") })
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.external.github]
+enabled = true
+client_id = "hello"
+secret = "env(TEST_GITHUB_SECRET)"`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "supabase/.env.local", []byte("TEST_GITHUB_SECRET=world\n"), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFSWithEnv(fsys, "supabase/.env.local"))
+		// Check config
+		assert.Equal(t, "world", Config.Auth.External["github"].Secret)
+		Config.Auth.External["github"] = provider{}
+	})
+
+	t.Run("loads default .env from fsys", func(t *testing.T) {
+		t.Cleanup(func() { os.Unsetenv("TEST_GITHUB_SECRET") })
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.external.github]
+enabled = true
+client_id = "hello"
+secret = "env(TEST_GITHUB_SECRET)"`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, EnvPath, []byte("TEST_GITHUB_SECRET=in-memory\n"), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, "in-memory", Config.Auth.External["github"].Secret)
+		Config.Auth.External["github"] = provider{}
+	})
+
+	t.Run("throws error on malformed file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, EnvPath, []byte("INVALID LINE\n"), 0644))
+		// Run test
+		err := loadEnvFiles(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse .env")
+	})
+}
+
+func TestInsecureDefaults(t *testing.T) {
+	siteUrl := Config.Auth.SiteUrl
+	defer func() { Config.Auth.SiteUrl = siteUrl }()
+
+	t.Run("warns on default secrets with public site url", func(t *testing.T) {
+		Config.Auth.SiteUrl = "https://example.com"
+		assert.Len(t, insecureDefaults(), 3)
+	})
+
+	t.Run("ignores local site url", func(t *testing.T) {
+		for _, local := range []string{"http://localhost:3000", "http://127.0.0.1:3000", "http://[::1]:3000", "http://app.localhost"} {
+			Config.Auth.SiteUrl = local
+			assert.Empty(t, insecureDefaults(), local)
+		}
+	})
+}
+
+func TestShouldVerifyJWT(t *testing.T) {
+	enabled, disabled := true, false
+
+	t.Run("defaults to true when unset", func(t *testing.T) {
+		assert.True(t, function{}.ShouldVerifyJWT())
+	})
+
+	t.Run("returns true when enabled", func(t *testing.T) {
+		assert.True(t, function{VerifyJWT: &enabled}.ShouldVerifyJWT())
+	})
+
+	t.Run("returns false when disabled", func(t *testing.T) {
+		assert.False(t, function{VerifyJWT: &disabled}.ShouldVerifyJWT())
+	})
+}
+
+func TestFunctionLimitsConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Functions = nil
+		Config.FunctionsDefault = function{}
+	}
+	teardown()
+
+	t.Run("parses timeout and memory", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[functions.hello]
+timeout = "60s"
+memory = "256MB"
+[functions.world]
+verify_jwt = false
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, durationInMilliseconds(60000), Config.Functions["hello"].Timeout)
+		assert.Equal(t, sizeInBytes(256*1024*1024), Config.Functions["hello"].Memory)
+		assert.Zero(t, Config.Functions["world"].Timeout)
+		assert.Zero(t, Config.Functions["world"].Memory)
+	})
+
+	t.Run("merges functions default", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[functions_default]
+verify_jwt = false
+timeout = 30000
+[functions.hello]
+timeout = "1m"
+[functions.world]
+verify_jwt = true
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.False(t, *Config.Functions["hello"].VerifyJWT)
+		assert.Equal(t, durationInMilliseconds(60000), Config.Functions["hello"].Timeout)
+		assert.True(t, *Config.Functions["world"].VerifyJWT)
+		assert.Equal(t, durationInMilliseconds(30000), Config.Functions["world"].Timeout)
+	})
+
+	t.Run("throws error on default timeout above limit", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[functions_default]
+timeout = "10m"
+[functions.hello]
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for functions.hello.timeout")
+	})
+
+	t.Run("accepts existing import map", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[functions.hello]
+import_map = "./functions/hello/import_map.json"
+[functions.world]
+import_map = "env(WORLD_IMPORT_MAP)"
+`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(FunctionsDir, "hello", "import_map.json"), []byte("{}"), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+	})
+
+	t.Run("throws error on missing import map", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[functions.hello]
+import_map = "./functions/hello/import-map.json"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for functions.hello.import_map: file not found: supabase/functions/hello/import-map.json")
+	})
+
+	t.Run("parses custom entrypoint", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[functions.hello]
+entrypoint = "src/main.tsx"
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, "src/main.tsx", Config.Functions["hello"].Entrypoint)
+	})
+
+	t.Run("throws error on invalid entrypoint", func(t *testing.T) {
+		for _, entrypoint := range []string{"/abs/index.ts", "index.py", "../../x.ts", "src/../../x.ts"} {
+			// Setup in-memory fs
+			fsys := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[functions.hello]
+entrypoint = "`+entrypoint+`"
+`), 0644))
+			// Run test
+			err := LoadConfigFS(fsys)
+			teardown()
+			// Check error
+			assert.ErrorContains(t, err, "Invalid config for functions.hello.entrypoint")
+		}
+	})
+
+	t.Run("throws error on entrypoint outside function directory", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[functions.hello]
+entrypoint = "../other/index.ts"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.EqualError(t, err, `Invalid config for functions.hello.entrypoint: "../other/index.ts" must be inside the function directory`)
+	})
+
+	t.Run("throws error on timeout above limit", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[functions.hello]
+timeout = 300001
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.EqualError(t, err, "Invalid config for functions.hello.timeout: must be between 100ms and 300000ms, got 300001ms")
+	})
+
+	t.Run("throws error on timeout below limit", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[functions.hello]
+timeout = 99
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.EqualError(t, err, "Invalid config for functions.hello.timeout: must be between 100ms and 300000ms, got 99ms")
+	})
+
+	t.Run("throws error on invalid memory", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[functions.hello]
+memory = "lots"
+`), 0644))
+		// Run test
+		assert.Error(t, LoadConfigFS(fsys))
+	})
+
+	t.Run("throws error on memory above limit", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[functions.hello]
+memory = "2GB"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for functions.hello.memory")
+	})
+}
+
+func TestSeedConfig(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.Email.Template["invite"] = emailTemplate{}
+		for name := range Config.Auth.External {
+			Config.Auth.External[name] = provider{}
+		}
+		Config.Db.Seed.Paths = nil
+	}
+	teardown()
+
+	t.Run("resolves seed globs in order", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[db.seed]
+paths = ["supabase/seed.sql", "supabase/seeds/*.sql", "supabase/missing/*.sql"]
+`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "supabase/seeds/b.sql", nil, 0644))
+		require.NoError(t, afero.WriteFile(fsys, "supabase/seeds/a.sql", nil, 0644))
+		require.NoError(t, afero.WriteFile(fsys, "supabase/seed.sql", nil, 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, []string{
+			"supabase/seed.sql",
+			"supabase/seeds/a.sql",
+			"supabase/seeds/b.sql",
+		}, Config.Db.Seed.SqlPaths)
+	})
+
+	t.Run("throws error on missing seed file", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[db.seed]
+paths = ["supabase/seed.sql"]
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for db.seed.paths: supabase/seed.sql does not exist")
+	})
+
+	t.Run("throws error on invalid glob", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`
+project_id = "test"
+[db.seed]
+paths = ["supabase/[seeds.sql"]
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for db.seed.paths")
+	})
+}
+
+func TestUnknownConfigKeys(t *testing.T) {
+	undecoded := []toml.Key{
+		{"auth", "enabel_signup"},
+		{"foo"},
+		{"foo", "bar"},
+	}
+
+	t.Run("warns on unknown keys", func(t *testing.T) {
+		assert.NoError(t, checkUnknownKeys(undecoded, false))
+	})
+
+	t.Run("throws error in strict mode", func(t *testing.T) {
+		err := checkUnknownKeys(undecoded, true)
+		assert.EqualError(t, err, "Unknown config keys: auth.enabel_signup (did you mean auth.enable_signup?), foo.bar")
+	})
+
+	t.Run("suggests closest key", func(t *testing.T) {
+		assert.Equal(t, "auth.jwt_expiry", suggestConfigKey("auth.jwt_exipry"))
+		assert.Equal(t, "auth.external.github.client_id", suggestConfigKey("auth.external.github.clientid"))
+		assert.Equal(t, "project_id", suggestConfigKey("projectid"))
+		assert.Empty(t, suggestConfigKey("auth.unrelated"))
+		assert.Empty(t, suggestConfigKey("foo.bar"))
+	})
+
+	t.Run("ignores empty metadata", func(t *testing.T) {
+		assert.NoError(t, checkUnknownKeys(nil, true))
+	})
+
+	t.Run("lists every unknown key when loading in strict mode", func(t *testing.T) {
+		viper.Set("STRICT-CONFIG", true)
+		defer viper.Set("STRICT-CONFIG", false)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth]
+jwt_exipry = 3600
+[studio]
+colour = "blue"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Unknown config keys: auth.jwt_exipry (did you mean auth.jwt_expiry?), studio.colour")
+	})
+}
+
+func TestEnabledExternalProviders(t *testing.T) {
+	t.Run("returns sorted enabled providers", func(t *testing.T) {
+		c := config{Auth: auth{External: map[string]provider{
+			"zoom":   {Enabled: true},
+			"apple":  {Enabled: true},
+			"github": {Enabled: false},
+			"google": {Enabled: true},
+		}}}
+		assert.Equal(t, []string{"apple", "google", "zoom"}, c.EnabledExternalProviders())
+	})
+
+	t.Run("returns empty slice when none enabled", func(t *testing.T) {
+		c := config{Auth: auth{External: map[string]provider{
+			"github": {},
+		}}}
+		providers := c.EnabledExternalProviders()
+		assert.NotNil(t, providers)
+		assert.Empty(t, providers)
+	})
+}
+
+func TestResetConfig(t *testing.T) {
+	original := `project_id = "my-project"
+
+[api]
+port = 1234
+
+[auth.sms.twilio]
+enabled = true
+auth_token = "env(TWILIO_AUTH_TOKEN)"
+
+[auth.external.apple]
+enabled = true
+secret = "env(APPLE_SECRET)"
+
+[auth.external.github]
+enabled = true
+secret = "env(GITHUB_SECRET)"
+`
+
+	t.Run("preserves project id and secrets", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(original), 0644))
+		// Run test
+		assert.NoError(t, ResetConfig(fsys, true, true))
+		// Check config
+		var reset config
+		_, err := toml.DecodeFS(afero.NewIOFS(fsys), ConfigPath, &reset)
+		require.NoError(t, err)
+		assert.Equal(t, "my-project", reset.ProjectId)
+		assert.Equal(t, uint(54321), reset.Api.Port)
+		assert.False(t, reset.Auth.Sms.Twilio.Enabled)
+		assert.Equal(t, "env(TWILIO_AUTH_TOKEN)", reset.Auth.Sms.Twilio.AuthToken)
+		assert.False(t, reset.Auth.External["apple"].Enabled)
+		assert.Equal(t, "env(APPLE_SECRET)", reset.Auth.External["apple"].Secret)
+		assert.Equal(t, "env(GITHUB_SECRET)", reset.Auth.External["github"].Secret)
+	})
+
+	t.Run("discards project id and secrets", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(original), 0644))
+		// Run test
+		assert.NoError(t, ResetConfig(fsys, false, false))
+		// Check config
+		var reset config
+		_, err := toml.DecodeFS(afero.NewIOFS(fsys), ConfigPath, &reset)
+		require.NoError(t, err)
+		assert.Equal(t, "utils", reset.ProjectId)
+		assert.Equal(t, "env(SUPABASE_AUTH_EXTERNAL_APPLE_SECRET)", reset.Auth.External["apple"].Secret)
+		assert.NotContains(t, reset.Auth.External, "github")
+	})
+
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.Error(t, ResetConfig(fsys, true, true))
+	})
+}
+
+func TestSetTomlValue(t *testing.T) {
+	content := `# comment
+project_id = "test"
+
+[api]
+# Port to use for the API URL.
+port = 54321
+# max_rows = 1000
+
+[db]
+port = 54322
+`
+
+	t.Run("replaces existing key", func(t *testing.T) {
+		updated := setTomlValue(content, "api", "port", "1234")
+		assert.Contains(t, updated, "# Port to use for the API URL.\nport = 1234\n")
+		assert.Contains(t, updated, "[db]\nport = 54322")
+	})
+
+	t.Run("uncomments commented key", func(t *testing.T) {
+		updated := setTomlValue(content, "api", "max_rows", "10")
+		assert.Contains(t, updated, "\nmax_rows = 10\n")
+	})
+
+	t.Run("inserts missing key", func(t *testing.T) {
+		updated := setTomlValue(content, "db", "shadow_port", "54320")
+		assert.Contains(t, updated, "[db]\nshadow_port = 54320\nport = 54322")
+	})
+
+	t.Run("appends missing table", func(t *testing.T) {
+		updated := setTomlValue(content, "studio", "port", "54323")
+		assert.True(t, strings.HasSuffix(updated, "\n\n[studio]\nport = 54323\n"))
+	})
+
+	t.Run("replaces top level key", func(t *testing.T) {
+		updated := setTomlValue(content, "", "project_id", tomlQuote("other"))
+		assert.Contains(t, updated, `project_id = "other"`)
+	})
+}
+
+func TestMigrateConfig(t *testing.T) {
+	t.Run("renames keys and stamps version", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+
+[db]
+# Kept comment
+port = 54322
+seed_paths = ["./supabase/seed.sql"]
+
+[db.seed]
+enabled = true
+`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "supabase/seed.sql", []byte{}, 0644))
+		// Run test
+		changes, err := MigrateConfig(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Renamed db.seed_paths to db.seed.paths", "Set config_version to 2"}, changes)
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, `config_version = 2
+project_id = "test"
+
+[db]
+# Kept comment
+port = 54322
+
+[db.seed]
+paths = ["./supabase/seed.sql"]
+enabled = true
+`, string(contents))
+	})
+
+	t.Run("throws error on newer version", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+config_version = 99
+`), 0644))
+		// Run test
+		changes, err := MigrateConfig(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for config_version: 99 is newer")
+		assert.Empty(t, changes)
+		// Check load error
+		assert.ErrorContains(t, LoadConfigFS(fsys), "Invalid config for config_version: 99 is newer")
+	})
+}
+
+func TestDbExtensions(t *testing.T) {
+	t.Run("accepts extension names", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[db]
+extensions = ["uuid-ossp", "pg_cron", "vector"]
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, []string{"uuid-ossp", "pg_cron", "vector"}, Config.Db.Extensions)
+	})
+
+	t.Run("throws error on invalid name", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[db]
+extensions = ["vector; drop table users"]
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, `Invalid config for db.extensions: "vector; drop table users" must only contain`)
+	})
+}
+
+func TestDeprecatedKeys(t *testing.T) {
+	t.Run("loads renamed keys into new fields", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		original := []byte(`project_id = "test"
+[db]
+seed_paths = ["supabase/seed.sql"]
+`)
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, original, 0644))
+		require.NoError(t, afero.WriteFile(fsys, "supabase/seed.sql", []byte{}, 0644))
+		// Run test
+		warnings, err := CollectWarnings(func() error { return LoadConfigFS(fsys) })
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"supabase/seed.sql"}, Config.Db.Seed.Paths)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "db.seed_paths is deprecated and was loaded as db.seed.paths.")
+		// Check file is unchanged
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, original, contents)
+	})
+
+	t.Run("prefers new key over deprecated key", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[db]
+seed_paths = ["supabase/old.sql"]
+[db.seed]
+paths = ["supabase/new.sql"]
+`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "supabase/new.sql", []byte{}, 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, []string{"supabase/new.sql"}, Config.Db.Seed.Paths)
+	})
+
+	t.Run("throws error on manual rename", func(t *testing.T) {
+		keys := []deprecatedKey{
+			{Old: "auth.jwt_expiry_ms", New: "auth.jwt_expiry", Manual: "Divide the value by 1000 to convert it to seconds."},
+		}
+		// Run test
+		_, _, err := renameDeprecatedKeys(`[auth]
+jwt_expiry_ms = 3600000
+`, keys)
+		// Check error
+		assert.EqualError(t, err, "Invalid config for auth.jwt_expiry_ms: replaced by auth.jwt_expiry. Divide the value by 1000 to convert it to seconds.")
+	})
+}
+
+func TestIsCompatibleVersion(t *testing.T) {
+	assert.True(t, IsCompatibleVersion("1.2.0", "1.2.3"))
+	assert.True(t, IsCompatibleVersion("v1.2.3", "1.5.0"))
+	assert.True(t, IsCompatibleVersion("1.2.3", "1.2.0"))
+	assert.False(t, IsCompatibleVersion("1.5.0", "1.2.3"))
+	assert.False(t, IsCompatibleVersion("1.2.3", "2.0.0"))
+	assert.False(t, IsCompatibleVersion("latest", "1.2.3"))
+	assert.True(t, IsCompatibleVersion("1.2.3", ""))
+}
+
+func TestCliVersionConfig(t *testing.T) {
+	defer func(version string) { Version = version }(Version)
+	Version = "2.0.0"
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+cli_version = "1.2.3"
+`), 0644))
+
+	t.Run("throws error on incompatible version", func(t *testing.T) {
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for cli_version: 1.2.3 is incompatible with Supabase CLI 2.0.0.")
+	})
+
+	t.Run("warns on mismatch when allowed", func(t *testing.T) {
+		viper.Set("ALLOW-VERSION-MISMATCH", true)
+		defer viper.Set("ALLOW-VERSION-MISMATCH", false)
+		// Run test
+		warnings, err := CollectWarnings(func() error { return LoadConfigFS(fsys) })
+		// Check error
+		assert.NoError(t, err)
+		assert.Len(t, warnings, 1)
+	})
+
+	t.Run("throws error on invalid version", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+cli_version = "latest"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for cli_version: must be a semantic version")
+	})
+}
+
+func TestFindTomlLine(t *testing.T) {
+	content := `project_id = "test"
+
+[api]
+# port = 1
+port = 54321
+
+[auth.external.github]
+enabled = true
+`
+	assert.Equal(t, 1, findTomlLine(content, "project_id"))
+	assert.Equal(t, 5, findTomlLine(content, "api.port"))
+	assert.Equal(t, 3, findTomlLine(content, "api.max_rows"))
+	assert.Equal(t, 7, findTomlLine(content, "auth.external.github.client_id"))
+	assert.Equal(t, 0, findTomlLine(content, "db.port"))
+}
+
+func TestPortCollisions(t *testing.T) {
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[inbucket]
+port = 54322
+[studio]
+enabled = false
+port = 54321
+`), 0644))
+	// Run test
+	warnings, err := CollectWarnings(func() error { return LoadConfigFS(fsys) })
+	// Check error
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"db.port and inbucket.port both use port 54322."}, warnings)
+}
+
+func TestAnalyticsPorts(t *testing.T) {
+	t.Run("throws error on same analytics ports", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[analytics]
+enabled = true
+port = 54327
+vector_port = 54327
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.EqualError(t, err, "Invalid config for analytics.vector_port: analytics.port and analytics.vector_port must be different, got 54327")
+	})
+
+	t.Run("throws error on port used by another service", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[analytics]
+enabled = true
+vector_port = 54323
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.EqualError(t, err, "Invalid config for analytics.vector_port: must differ from studio.port, got 54323")
+	})
+
+	t.Run("accepts port used inside the docker network", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[realtime]
+port = 4000
+[analytics]
+enabled = true
+port = 4000
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+	})
+
+	t.Run("ignores ports of disabled analytics", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[analytics]
+enabled = false
+port = 54323
+vector_port = 54323
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+	})
+}
+
 func TestFileSizeLimitConfigParsing(t *testing.T) {
 	t.Run("test file size limit parsing number", func(t *testing.T) {
 		var testConfig config
@@ -133,4 +3173,544 @@ func TestSanitizeProjectI(t *testing.T) {
 	assert.Equal(t, "abc", sanitizeProjectId("_@abc"))
 	// Replaces consecutive invalid characters with a single _
 	assert.Equal(t, "a_bc-", sanitizeProjectId("a@@bc-"))
+	// Truncates to max length
+	assert.Len(t, sanitizeProjectId(strings.Repeat("a", 100)), maxProjectIdLength)
+}
+
+func TestValidateProjectId(t *testing.T) {
+	assert.NoError(t, validateProjectId("my-app.v2_test"))
+	assert.NoError(t, validateProjectId(strings.Repeat("a", maxProjectIdLength)))
+
+	t.Run("throws error on leading special character", func(t *testing.T) {
+		err := validateProjectId("_app")
+		assert.EqualError(t, err, `Invalid config for project_id: "_app" must start with a letter or digit and only contain letters, digits and _.-`)
+		var configErr *ConfigError
+		require.ErrorAs(t, err, &configErr)
+		assert.Contains(t, configErr.Suggestion, `"app"`)
+	})
+
+	t.Run("throws error on invalid character", func(t *testing.T) {
+		err := validateProjectId("my app")
+		assert.ErrorContains(t, err, `"my app" must start with a letter or digit`)
+	})
+
+	t.Run("throws error on long project id", func(t *testing.T) {
+		err := validateProjectId(strings.Repeat("a", 64))
+		assert.EqualError(t, err, "Invalid config for project_id: must be at most 63 characters, got 64")
+	})
+}
+
+func TestConfigDiff(t *testing.T) {
+	t.Run("reports changed sections", func(t *testing.T) {
+		old := Config.Clone()
+		updated := old.Clone()
+		updated.Auth.External["github"] = provider{Enabled: true}
+		updated.Studio.Port = 1234
+		// Run test
+		diff := ConfigDiff(old, updated)
+		// Check result
+		assert.Len(t, diff, 2)
+		assert.Equal(t, updated.Auth, diff["auth"])
+		assert.Equal(t, updated.Studio, diff["studio"])
+		// Original maps are unchanged
+		assert.False(t, old.Auth.External["github"].Enabled)
+	})
+
+	t.Run("ignores unchanged config", func(t *testing.T) {
+		assert.Empty(t, ConfigDiff(Config, Config.Clone()))
+	})
+}
+
+func TestOrderedFunctions(t *testing.T) {
+	t.Run("preserves declaration order of tables", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[functions.zeta]
+timeout = 1000
+[functions.alpha]
+verify_jwt = false
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		functions := Config.OrderedFunctions()
+		require.Len(t, functions, 2)
+		assert.Equal(t, "zeta", functions[0].Name)
+		assert.Equal(t, durationInMilliseconds(1000), functions[0].Timeout)
+		assert.Equal(t, "alpha", functions[1].Name)
+		assert.False(t, functions[1].ShouldVerifyJWT())
+	})
+
+	t.Run("loads array of tables", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		original := []byte(`project_id = "test"
+[[functions]]
+name = "world"
+timeout = 2000
+
+[[functions]]
+name = "hello"
+verify_jwt = false
+[functions_default]
+timeout = 500
+`)
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, original, 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		functions := Config.OrderedFunctions()
+		require.Len(t, functions, 2)
+		assert.Equal(t, "world", functions[0].Name)
+		assert.Equal(t, durationInMilliseconds(2000), functions[0].Timeout)
+		assert.Equal(t, "hello", functions[1].Name)
+		assert.Equal(t, durationInMilliseconds(500), functions[1].Timeout)
+		assert.False(t, Config.Functions["hello"].ShouldVerifyJWT())
+		// Check file is unchanged
+		contents, err := afero.ReadFile(fsys, ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, original, contents)
+	})
+
+	t.Run("appends undeclared functions in sorted order", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[functions.world]
+`), 0644))
+		require.NoError(t, LoadConfigFS(fsys))
+		Config.Functions["beta"] = function{}
+		Config.Functions["alpha"] = function{}
+		// Run test
+		functions := Config.OrderedFunctions()
+		// Check order
+		var names []string
+		for _, f := range functions {
+			names = append(names, f.Name)
+		}
+		assert.Equal(t, []string{"world", "alpha", "beta"}, names)
+	})
+
+	t.Run("throws error on missing name", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[[functions]]
+timeout = 20
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.EqualError(t, err, "Invalid config for functions: entry 1 is missing a name")
+	})
+
+	t.Run("throws error on duplicate name", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[[functions]]
+name = "hello"
+[[functions]]
+name = "hello"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.EqualError(t, err, "Invalid config for functions: hello is declared more than once")
+	})
+}
+
+func TestContainerIds(t *testing.T) {
+	ids := ContainerIds("test")
+	assert.Equal(t, "supabase_network_test", ids.NetId)
+	assert.Equal(t, "supabase_db_test", ids.DbId)
+	assert.Equal(t, "supabase_kong_test", ids.KongId)
+	assert.Equal(t, "realtime-dev.supabase_realtime_test", ids.RealtimeId)
+	assert.Equal(t, "supabase_pooler_test", ids.PoolerId)
+}
+
+func TestConfigSchema(t *testing.T) {
+	t.Run("matches committed schema", func(t *testing.T) {
+		// Run test
+		schema, err := ConfigSchema()
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, ConfigSchemaJson, string(schema), "Run go generate ./internal/utils to update templates/config.schema.json")
+	})
+
+	t.Run("describes keys from template comments", func(t *testing.T) {
+		descriptions := templateDescriptions(`# Ignored
+project_id = "test"
+
+[api]
+# Port to use.
+# Applies locally.
+port = 54321
+# An example:
+# schemas = ["public"]
+max_rows = 1000
+`)
+		assert.Equal(t, map[string]string{
+			"project_id": "Ignored",
+			"api.port":   "Port to use. Applies locally.",
+		}, descriptions)
+	})
+}
+
+func TestCustomProvider(t *testing.T) {
+	// Reset global variable
+	teardown := func() {
+		Config.Auth.External = defaultProviders()
+	}
+
+	t.Run("passes unknown provider to gotrue", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.external.my-oidc]
+enabled = true
+client_id = "hello"
+secret = "env(TEST_SECRET:-world)"
+url = "https://example.com"
+`), 0644))
+		// Run test
+		warnings, err := CollectWarnings(func() error { return LoadConfigFS(fsys) })
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "auth.external.my-oidc is not a known provider")
+		env := Config.AuthEnv()
+		assert.Equal(t, "true", env["GOTRUE_EXTERNAL_MY_OIDC_ENABLED"])
+		assert.Equal(t, "hello", env["GOTRUE_EXTERNAL_MY_OIDC_CLIENT_ID"])
+		assert.Equal(t, "https://example.com", env["GOTRUE_EXTERNAL_MY_OIDC_URL"])
+	})
+
+	t.Run("throws error on invalid provider name", func(t *testing.T) {
+		defer teardown()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.external."my.oidc"]
+enabled = true
+client_id = "hello"
+secret = "env(TEST_SECRET:-world)"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for auth.external.my.oidc: provider name must only contain")
+	})
+}
+
+func TestProviderEnvPrefix(t *testing.T) {
+	assert.True(t, isKnownProvider("linkedin_oidc"))
+	assert.False(t, isKnownProvider("my-oidc"))
+	assert.Equal(t, "GOTRUE_EXTERNAL_LINKEDIN_OIDC", providerEnvPrefix("linkedin_oidc"))
+	assert.Equal(t, "GOTRUE_EXTERNAL_MY_OIDC", providerEnvPrefix("my-oidc"))
+}
+
+func TestListSecrets(t *testing.T) {
+	t.Setenv("S3_ACCESS_KEY", "test")
+	t.Setenv("SEND_SMS_SECRETS", "")
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[api]
+schemas = ["public", "env(EXTRA_SCHEMA:-graphql_public)"]
+[storage.s3]
+access_key = "env(S3_ACCESS_KEY)"
+[auth.hook.send_sms]
+secrets = "env(SEND_SMS_SECRETS)"
+`), 0644))
+	raw, err := ReadConfigFS(fsys)
+	require.NoError(t, err)
+	// Run test
+	refs := ListSecrets(raw)
+	// Check secrets
+	assert.Equal(t, []SecretRef{
+		{Name: "EXTRA_SCHEMA", Key: "api.schemas[1]", HasDefault: true},
+		{Name: "SEND_SMS_SECRETS", Key: "auth.hook.send_sms.secrets"},
+		{Name: "S3_ACCESS_KEY", Key: "storage.s3.access_key", Set: true},
+	}, refs)
+}
+
+func TestPlaintextSecrets(t *testing.T) {
+	config := []byte(`project_id = "test"
+[auth.sms.twilio]
+enabled = true
+account_sid = "sid"
+message_service_sid = "msid"
+auth_token = "literal"
+[auth.email.smtp]
+host = "smtp.example.com"
+port = 587
+pass = "env(SMTP_PASS:-password)"
+`)
+
+	t.Run("throws error on literal secret", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, config, 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.EqualError(t, err, `Invalid config for auth.sms.twilio.auth_token: secrets must not be stored in plaintext. Use auth_token = "env(SUPABASE_AUTH_SMS_TWILIO_AUTH_TOKEN)" instead.`)
+		var configErr *ConfigError
+		require.ErrorAs(t, err, &configErr)
+		assert.Contains(t, configErr.Suggestion, "--allow-plaintext-secrets")
+	})
+
+	t.Run("loads literal secret with flag", func(t *testing.T) {
+		viper.Set("ALLOW-PLAINTEXT-SECRETS", true)
+		defer viper.Set("ALLOW-PLAINTEXT-SECRETS", false)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, config, 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, "literal", Config.Auth.Sms.Twilio.AuthToken)
+		assert.Equal(t, "password", Config.Auth.Email.Smtp.Pass)
+	})
+}
+
+func TestGenerateProviderTemplate(t *testing.T) {
+	t.Run("generates loadable oauth provider", func(t *testing.T) {
+		t.Setenv("SUPABASE_AUTH_EXTERNAL_GITHUB_CLIENT_ID", "client")
+		t.Setenv("SUPABASE_AUTH_EXTERNAL_GITHUB_SECRET", "secret")
+		snippet := GenerateProviderTemplate("auth.external.github")
+		assert.Contains(t, snippet, "[auth.external.github]\n")
+		assert.Contains(t, snippet, `secret = "env(SUPABASE_AUTH_EXTERNAL_GITHUB_SECRET)"`)
+		assert.NotContains(t, snippet, "url = ")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte("project_id = \"test\"\n"+snippet), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, "client", Config.Auth.External["github"].ClientId)
+		assert.Equal(t, "secret", Config.Auth.External["github"].Secret)
+	})
+
+	t.Run("includes provider specific fields", func(t *testing.T) {
+		assert.Contains(t, GenerateProviderTemplate("auth.external.azure"), "\nurl = \"\"\n")
+		assert.Contains(t, GenerateProviderTemplate("auth.external.apple"), `team_id = "env(SUPABASE_AUTH_EXTERNAL_APPLE_TEAM_ID)"`)
+		assert.Contains(t, GenerateProviderTemplate("auth.sms.vonage"), `api_secret = "env(SUPABASE_AUTH_SMS_VONAGE_API_SECRET)"`)
+		assert.Contains(t, GenerateProviderTemplate("storage.s3"), "[storage]\n# Store objects")
+	})
+
+	t.Run("generates valid toml", func(t *testing.T) {
+		for _, key := range []string{"auth.sms.twilio", "storage.s3", "functions.hello-world", "auth.external.my-oidc"} {
+			var decoded map[string]interface{}
+			_, err := toml.Decode(GenerateProviderTemplate(key), &decoded)
+			assert.NoError(t, err, key)
+			assert.NotEmpty(t, decoded, key)
+		}
+	})
+
+	t.Run("returns empty for unsupported table", func(t *testing.T) {
+		assert.Empty(t, GenerateProviderTemplate("auth.sms.unknown"))
+		assert.Empty(t, GenerateProviderTemplate("functions.bad name"))
+		assert.Empty(t, GenerateProviderTemplate("api"))
+	})
+}
+
+func TestDisabledServicePorts(t *testing.T) {
+	services := []struct {
+		table string
+		// Config that leaves the required field unset
+		missing string
+		errMsg  string
+	}{
+		{"api", "port = 0", "Missing required field in config: api.port"},
+		{"realtime", "port = 0", "Missing required field in config: realtime.port"},
+		{"studio", "port = 0", "Missing required field in config: studio.port"},
+		{"inbucket", "port = 0", "Missing required field in config: inbucket.port"},
+		{"db.pooler", "port = 0", "Missing required field in config: db.pooler.port"},
+		{"analytics", "port = 0", "Missing required field in config: analytics.port"},
+		{"analytics", "vector_port = 0", "Missing required field in config: analytics.vector_port"},
+		{"analytics", `backend = "bigquery"`, "Invalid config for analytics.gcp_project_id: required when backend is bigquery"},
+	}
+
+	for _, s := range services {
+		for _, enabled := range []bool{true, false} {
+			name := fmt.Sprintf("%s with %s enabled %t", s.table, s.missing, enabled)
+			t.Run(name, func(t *testing.T) {
+				// Setup in-memory fs
+				fsys := afero.NewMemMapFs()
+				config := fmt.Sprintf("project_id = \"test\"\n[%s]\nenabled = %t\n%s\n", s.table, enabled, s.missing)
+				require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(config), 0644))
+				// Run test
+				err := LoadConfigFS(fsys)
+				// Check error
+				if enabled {
+					assert.EqualError(t, err, s.errMsg)
+				} else {
+					assert.NoError(t, err)
+				}
+			})
+		}
+	}
+}
+
+func TestDbTimeouts(t *testing.T) {
+	t.Run("parses timeouts as milliseconds or duration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[db.timeouts]
+statement_timeout = "30s"
+idle_in_transaction_session_timeout = 500
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check resolved value
+		assert.Equal(t, durationInMilliseconds(30000), Config.Db.Timeouts.StatementTimeout)
+		assert.Equal(t, durationInMilliseconds(500), Config.Db.Timeouts.IdleInTransactionSessionTimeout)
+		text, err := Config.Db.Timeouts.StatementTimeout.MarshalText()
+		assert.NoError(t, err)
+		assert.Equal(t, "30s", string(text))
+	})
+
+	t.Run("throws error on negative timeout", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[db.timeouts]
+idle_in_transaction_session_timeout = "-5m"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.EqualError(t, err, "Invalid config for db.timeouts.idle_in_transaction_session_timeout: must be positive, got -300000ms")
+	})
+}
+
+func TestInbucketSender(t *testing.T) {
+	t.Run("throws error on invalid admin email", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[inbucket]
+enabled = true
+admin_email = "Admin <admin@email.com>"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.EqualError(t, err, `Invalid config for inbucket.admin_email: "Admin <admin@email.com>" must be an email address, such as admin@email.com`)
+	})
+
+	t.Run("throws error on blank sender name", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[inbucket]
+enabled = true
+sender_name = "  "
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.EqualError(t, err, "Invalid config for inbucket.sender_name: must not be blank")
+	})
+}
+
+func TestAnalyticsBackend(t *testing.T) {
+	t.Run("defaults to postgres backend", func(t *testing.T) {
+		original := Config.Analytics
+		defer func() { Config.Analytics = original }()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[analytics]
+enabled = true
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check resolved value
+		assert.Equal(t, LogflarePostgres, Config.Analytics.Backend)
+	})
+
+	t.Run("skips gcp settings with postgres backend", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[analytics]
+enabled = true
+backend = "postgres"
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+	})
+
+	t.Run("throws error on missing gcp settings", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[analytics]
+enabled = true
+backend = "bigquery"
+gcp_project_id = "test-project"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.EqualError(t, err, "Invalid config for analytics.gcp_project_number: required when backend is bigquery")
+	})
+
+	t.Run("throws error on missing gcp key file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[analytics]
+enabled = true
+backend = "bigquery"
+gcp_project_id = "test-project"
+gcp_project_number = "123456"
+gcp_jwt_path = "supabase/gcloud.json"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.EqualError(t, err, "Invalid config for analytics.gcp_jwt_path: supabase/gcloud.json does not exist")
+		// Load with key file
+		require.NoError(t, afero.WriteFile(fsys, "supabase/gcloud.json", []byte("{}"), 0600))
+		assert.NoError(t, LoadConfigFS(fsys))
+	})
+
+	t.Run("sets log level without gcp settings", func(t *testing.T) {
+		original := Config.Analytics
+		defer func() { Config.Analytics = original }()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[analytics]
+enabled = true
+log_level = "debug"
+`), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check resolved value
+		assert.Equal(t, LogflarePostgres, Config.Analytics.Backend)
+		assert.Equal(t, LogLevelDebug, Config.Analytics.LogLevel)
+		assert.Empty(t, Config.Analytics.GcpProjectId)
+	})
+
+	t.Run("throws error on invalid log level", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[analytics]
+enabled = true
+backend = "postgres"
+log_level = "verbose"
+`), 0644))
+		// Run test
+		err := LoadConfigFS(fsys)
+		// Check error
+		assert.EqualError(t, err, "Invalid config for analytics.log_level: must be one of: [debug info warn error]")
+	})
 }
@@ -0,0 +1,223 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/afero"
+)
+
+// Returns the value of a dotted config key, such as api.port or auth.external.github, from c.
+func GetConfigKey(c config, path string) (interface{}, error) {
+	field, err := findConfigField(c, path)
+	if err != nil {
+		return nil, err
+	}
+	return field.Interface(), nil
+}
+
+// Returns true if the dotted config key holds a secret that should not be printed by default.
+func IsSecretConfigKey(key string) bool {
+	for _, secret := range Config.secretFields() {
+		if secret.Table+"."+secret.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Sets a dotted config key in config.toml with a targeted edit that preserves comments and
+// unrelated content. The updated file is validated with the same rules as LoadConfigFS before
+// it is written.
+func SetConfigValue(key, value string, fsys afero.Fs) error {
+	field, err := findConfigField(Config, key)
+	if err != nil {
+		return err
+	}
+	parts := strings.Split(key, ".")
+	table, name := strings.Join(parts[:len(parts)-1], "."), parts[len(parts)-1]
+	for _, secret := range Config.secretFields() {
+		if secret.Table == table && secret.Key == name && !envPattern.MatchString(value) {
+			return fmt.Errorf("%s is a secret. Store it in %s and set the value to %s instead.", key, Bold(EnvPath), Aqua("env(NAME)"))
+		}
+	}
+	encoded, err := encodeConfigValue(field.Kind(), value)
+	if err != nil {
+		return invalidField(key, "%v", err)
+	}
+	original, err := afero.ReadFile(fsys, ConfigPath)
+	if err != nil {
+		return err
+	}
+	updated := []byte(setTomlValue(string(original), table, name, encoded))
+	overlay := afero.NewCopyOnWriteFs(fsys, afero.NewMemMapFs())
+	if err := afero.WriteFile(overlay, ConfigPath, updated, 0644); err != nil {
+		return err
+	}
+	if err := LoadConfigFS(overlay); err != nil {
+		return err
+	}
+	return afero.WriteFile(fsys, ConfigPath, updated, 0644)
+}
+
+// Encodes a command line value as an inline TOML value for a field of the given kind. Strings
+// may be passed with or without quotes, while other kinds must be valid TOML literals.
+func encodeConfigValue(kind reflect.Kind, value string) (string, error) {
+	var decoded map[string]interface{}
+	_, err := toml.Decode("value = "+value, &decoded)
+	if kind == reflect.String {
+		if s, ok := decoded["value"].(string); err == nil && ok {
+			return tomlQuote(s), nil
+		}
+		return tomlQuote(value), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("%q is not a valid TOML value", value)
+	}
+	return tomlValue(decoded["value"]), nil
+}
+
+// Walks the loaded Config by toml tags. Missing map entries resolve to their zero value, so
+// that new tables such as functions.<name> can be set.
+func findConfigField(c config, key string) (reflect.Value, error) {
+	if len(key) == 0 {
+		return reflect.Value{}, errors.New("config key must not be empty")
+	}
+	current := reflect.ValueOf(c)
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		switch current.Kind() {
+		case reflect.Struct:
+			next, err := findStructField(current, part, strings.Join(parts[:i+1], "."))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			current = next
+		case reflect.Map:
+			next := current.MapIndex(reflect.ValueOf(part))
+			if !next.IsValid() {
+				next = reflect.Zero(current.Type().Elem())
+			}
+			current = next
+		default:
+			return reflect.Value{}, fmt.Errorf("Unknown config key: %s", key)
+		}
+		if current.Kind() == reflect.Pointer {
+			if current.IsNil() {
+				current = reflect.Zero(current.Type().Elem())
+			} else {
+				current = current.Elem()
+			}
+		}
+	}
+	return current, nil
+}
+
+func findStructField(v reflect.Value, name, path string) (reflect.Value, error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("toml"), ",")[0]
+		if tag == name {
+			return v.Field(i), nil
+		}
+		if env := field.Tag.Get("mapstructure"); tag == "-" && env == name {
+			envName := "SUPABASE_" + strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+			return reflect.Value{}, fmt.Errorf("%s is a secret and is not stored in %s. Set %s in %s instead.", path, Bold(ConfigPath), envName, Bold(EnvPath))
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("Unknown config key: %s", path)
+}
+
+// Returns the line in config.toml that sets a dotted key or its enclosing table, or 0 if unknown.
+func FindConfigLine(fsys afero.Fs, key string) int {
+	contents, err := afero.ReadFile(fsys, ConfigPath)
+	if err != nil {
+		return 0
+	}
+	return findTomlLine(string(contents), key)
+}
+
+// An env() reference in config.toml.
+type SecretRef struct {
+	// Name of the environment variable
+	Name string `json:"name"`
+	// Dotted path to the config key that references it
+	Key string `json:"key"`
+	// True if the variable is set in the environment
+	Set bool `json:"set"`
+	// True if the reference has a default, as in env(NAME:default)
+	HasDefault bool `json:"has_default"`
+}
+
+// Decodes config.toml as written, without defaults, validation or env substitution.
+func ReadConfigFS(fsys afero.Fs) (config, error) {
+	var result config
+	original, err := afero.ReadFile(fsys, ConfigPath)
+	if err != nil {
+		return result, err
+	}
+	_, err = toml.Decode(string(original), &result)
+	return result, err
+}
+
+// Collects every env() reference in an unresolved config, such as one returned by ReadConfigFS,
+// sorted by key.
+func ListSecrets(c config) []SecretRef {
+	var result []SecretRef
+	collectSecrets(reflect.ValueOf(c), "", &result)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+func collectSecrets(v reflect.Value, path string, result *[]SecretRef) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			collectSecrets(v.Elem(), path, result)
+		}
+	case reflect.String:
+		if matches := envPattern.FindStringSubmatch(v.String()); len(matches) > 0 {
+			*result = append(*result, SecretRef{
+				Name:       matches[1],
+				Key:        path,
+				Set:        len(os.Getenv(matches[1])) > 0,
+				HasDefault: len(matches[2]) > 0,
+			})
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			collectSecrets(v.Index(i), fmt.Sprintf("%s[%d]", path, i), result)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			collectSecrets(iter.Value(), path+"."+iter.Key().String(), result)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := strings.Split(field.Tag.Get("toml"), ",")[0]
+			if field.Anonymous && len(tag) == 0 {
+				collectSecrets(v.Field(i), path, result)
+				continue
+			}
+			if len(tag) == 0 || tag == "-" {
+				continue
+			}
+			key := tag
+			if len(path) > 0 {
+				key = path + "." + tag
+			}
+			collectSecrets(v.Field(i), key, result)
+		}
+	}
+}
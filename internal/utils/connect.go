@@ -11,7 +11,6 @@ import (
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
-	"github.com/spf13/viper"
 	"github.com/supabase/cli/internal/debug"
 )
 
@@ -98,7 +97,7 @@ func ConnectByUrl(ctx context.Context, url string, options ...func(*pgx.ConnConf
 	for _, op := range options {
 		op(config)
 	}
-	if viper.GetBool("DEBUG") {
+	if DebugEnabled() {
 		debug.SetupPGX(config)
 	}
 	// Connect to database
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+const maxDebugLogLines = 50
+
+// Retains the most recent debug log lines so they can be attached to crash dumps.
+var DebugLogs = &lineBuffer{max: maxDebugLogLines}
+
+type lineBuffer struct {
+	mu      sync.Mutex
+	max     int
+	lines   []string
+	partial []byte
+}
+
+func (b *lineBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.partial = append(b.partial, p...)
+	for {
+		index := bytes.IndexByte(b.partial, '\n')
+		if index < 0 {
+			break
+		}
+		b.lines = append(b.lines, string(b.partial[:index]))
+		b.partial = b.partial[index+1:]
+	}
+	if len(b.lines) > b.max {
+		b.lines = b.lines[len(b.lines)-b.max:]
+	}
+	return len(p), nil
+}
+
+func (b *lineBuffer) Lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string{}, b.lines...)
+}
+
+// Writes a crash dump for the recovered panic and prints instructions for reporting
+// it. Returns the exit code the CLI should terminate with. The CLI has no telemetry to
+// opt in to, so the dump is never submitted automatically; users attach it to an issue.
+func ReportPanic(fsys afero.Fs, recovered any, stack []byte, args []string, w io.Writer) int {
+	fmt.Fprintln(w, Red(RedactSecrets(fmt.Sprintf("Supabase CLI crashed unexpectedly: %v", recovered))))
+	dumpPath, err := WriteCrashDump(fsys, recovered, stack, args)
+	if err != nil {
+		fmt.Fprintln(w, "Failed to write crash dump:", err)
+		fmt.Fprintln(w, RedactSecrets(string(stack)))
+	} else {
+		fmt.Fprintln(w, "A crash report has been saved to "+Bold(dumpPath))
+	}
+	fmt.Fprintln(w, "Please review the report for sensitive data and attach it to a new issue at https://github.com/supabase/cli/issues/new/choose")
+	return 1
+}
+
+func WriteCrashDump(fsys afero.Fs, recovered any, stack []byte, args []string) (string, error) {
+	var dump strings.Builder
+	fmt.Fprintln(&dump, "Version:", Version)
+	fmt.Fprintf(&dump, "Platform: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintln(&dump, "Command:", strings.Join(args, " "))
+	fmt.Fprintln(&dump, "Panic:", recovered)
+	fmt.Fprintf(&dump, "\nStack trace:\n%s\n", stack)
+	fmt.Fprintf(&dump, "\nDebug logs:\n%s\n", strings.Join(DebugLogs.Lines(), "\n"))
+	dumpPath := filepath.Join(CrashDir, GetCurrentTimestamp()+".txt")
+	if err := WriteFile(dumpPath, []byte(RedactSecrets(dump.String())), fsys); err != nil {
+		return "", err
+	}
+	return dumpPath, nil
+}
@@ -313,7 +313,7 @@ func DockerRemove(containerId string) {
 // Runs a container image exactly once, returning stdout and throwing error on non-zero exit code.
 func DockerRunOnce(ctx context.Context, image string, env []string, cmd []string) (string, error) {
 	stderr := io.Discard
-	if viper.GetBool("DEBUG") {
+	if DebugEnabled() {
 		stderr = os.Stderr
 	}
 	var out bytes.Buffer
@@ -369,7 +369,7 @@ func DockerStreamLogs(ctx context.Context, container string, stdout, stderr io.W
 // Exec a command once inside a container, returning stdout and throwing error on non-zero exit code.
 func DockerExecOnce(ctx context.Context, container string, env []string, cmd []string) (string, error) {
 	stderr := io.Discard
-	if viper.GetBool("DEBUG") {
+	if DebugEnabled() {
 		stderr = os.Stderr
 	}
 	var out bytes.Buffer
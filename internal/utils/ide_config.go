@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/google/uuid"
+	"github.com/spf13/afero"
+)
+
+const (
+	IDEVscode   = "vscode"
+	IDEIntellij = "intellij"
+)
+
+var (
+	VscodeSettingsPath  = filepath.Join(".vscode", "settings.json")
+	IntellijDataSources = filepath.Join(".idea", "dataSources.xml")
+
+	//go:embed templates/dataSources.xml
+	intellijDataSourcesEmbed    string
+	intellijDataSourcesTemplate = template.Must(template.New("dataSources").Parse(intellijDataSourcesEmbed))
+)
+
+// Settings added to the project's VS Code settings, unless the user already set them.
+var vscodeProjectSettings = map[string]interface{}{
+	"[sql]": map[string]interface{}{
+		"editor.formatOnSave": true,
+	},
+}
+
+// Writes editor config for the local project, such as the local db connection. Supported ides
+// are IDEVscode and IDEIntellij. Existing user settings are never overwritten.
+func GenerateIDEConfig(ide string, c config, fsys afero.Fs) error {
+	switch ide {
+	case IDEVscode:
+		return writeVscodeSettings(fsys)
+	case IDEIntellij:
+		return writeIntellijDataSources(c, fsys)
+	}
+	return fmt.Errorf("Unsupported IDE: %s. Must be one of: %v", ide, []string{IDEVscode, IDEIntellij})
+}
+
+// Merges settings into an existing settings.json, keeping any value the user has set.
+func writeVscodeSettings(fsys afero.Fs) error {
+	settings := map[string]interface{}{}
+	if contents, err := afero.ReadFile(fsys, VscodeSettingsPath); err == nil {
+		if err := json.Unmarshal(contents, &settings); err != nil {
+			// VS Code allows comments, which we cannot preserve when rewriting
+			return fmt.Errorf("failed to parse %s: %w. Add %s to it manually.", Bold(VscodeSettingsPath), err, Aqua(`"[sql]": {"editor.formatOnSave": true}`))
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for key, value := range vscodeProjectSettings {
+		if _, ok := settings[key]; !ok {
+			settings[key] = value
+		}
+	}
+	encoded, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFile(VscodeSettingsPath, append(encoded, '\n'), fsys)
+}
+
+func writeIntellijDataSources(c config, fsys afero.Fs) error {
+	if exists, err := afero.Exists(fsys, IntellijDataSources); err != nil {
+		return err
+	} else if exists {
+		return errors.New(Bold(IntellijDataSources) + " already exists. Add the local database with the connection string from " + Aqua("supabase status") + " instead.")
+	}
+	var buf bytes.Buffer
+	if err := intellijDataSourcesTemplate.Execute(&buf, struct {
+		Name string
+		Uuid string
+		Port uint
+	}{
+		Name: "supabase_local_" + c.ProjectId,
+		Uuid: uuid.NewString(),
+		Port: c.Db.Port,
+	}); err != nil {
+		return err
+	}
+	return WriteFile(IntellijDataSources, buf.Bytes(), fsys)
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateIDEConfig(t *testing.T) {
+	t.Run("creates vscode settings", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.NoError(t, GenerateIDEConfig(IDEVscode, Config, fsys))
+		// Check settings
+		contents, err := afero.ReadFile(fsys, VscodeSettingsPath)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"[sql]": {"editor.formatOnSave": true}}`, string(contents))
+	})
+
+	t.Run("keeps existing vscode settings", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, VscodeSettingsPath, []byte(`{"[sql]": {"editor.formatOnSave": false}, "editor.tabSize": 4}`), 0644))
+		// Run test
+		assert.NoError(t, GenerateIDEConfig(IDEVscode, Config, fsys))
+		// Check settings
+		contents, err := afero.ReadFile(fsys, VscodeSettingsPath)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"[sql]": {"editor.formatOnSave": false}, "editor.tabSize": 4}`, string(contents))
+	})
+
+	t.Run("throws error on vscode settings with comments", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		original := []byte("{\n  // user comment\n}\n")
+		require.NoError(t, afero.WriteFile(fsys, VscodeSettingsPath, original, 0644))
+		// Run test
+		err := GenerateIDEConfig(IDEVscode, Config, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse")
+		contents, err := afero.ReadFile(fsys, VscodeSettingsPath)
+		assert.NoError(t, err)
+		assert.Equal(t, original, contents)
+	})
+
+	t.Run("creates intellij data source", func(t *testing.T) {
+		var c config
+		c.ProjectId = "test"
+		c.Db.Port = 54322
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.NoError(t, GenerateIDEConfig(IDEIntellij, c, fsys))
+		// Check data source
+		contents, err := afero.ReadFile(fsys, IntellijDataSources)
+		assert.NoError(t, err)
+		assert.Contains(t, string(contents), `name="supabase_local_test"`)
+		assert.Contains(t, string(contents), "<jdbc-url>jdbc:postgresql://localhost:54322/postgres?user=postgres</jdbc-url>")
+	})
+
+	t.Run("throws error on existing intellij data sources", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, IntellijDataSources, []byte("<project/>"), 0644))
+		// Run test
+		err := GenerateIDEConfig(IDEIntellij, Config, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "already exists")
+	})
+
+	t.Run("throws error on unsupported ide", func(t *testing.T) {
+		err := GenerateIDEConfig("emacs", Config, afero.NewMemMapFs())
+		assert.EqualError(t, err, "Unsupported IDE: emacs. Must be one of: [vscode intellij]")
+	})
+}
@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+)
+
+var logLevels = map[LogLevel]int{
+	LogLevelDebug: 0,
+	LogLevelInfo:  1,
+	LogLevelWarn:  2,
+	LogLevelError: 3,
+}
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+var logger = struct {
+	sync.Mutex
+	config logging
+	fsys   afero.Fs
+	out    io.Writer
+	file   afero.File
+	// Process stderr before it is redirected for json output
+	stderr *os.File
+	// Write end of the pipe that replaces os.Stderr, closed to stop the json writer
+	pipe *os.File
+	done chan struct{}
+}{
+	config: logging{Level: LogLevelInfo, Format: LogFormatText, Output: "stderr"},
+	out:    os.Stderr,
+	stderr: os.Stderr,
+}
+
+// Applies the [logging] config to the std logger, warnings and anything else the CLI writes to
+// stderr. Applying the same config again is a no-op. Debug logs are still retained in memory for
+// crash reports regardless of the configured output.
+func ConfigureLogger(config logging, fsys afero.Fs) error {
+	logger.Lock()
+	defer logger.Unlock()
+	if config == logger.config && (fsys == logger.fsys || logger.file == nil) {
+		return nil
+	}
+	// Restore stderr before reopening the output, so that pending lines are written to the old one
+	stopJsonStderr()
+	out, file := logger.out, logger.file
+	if config.Output != logger.config.Output || fsys != logger.fsys {
+		switch config.Output {
+		case "stdout":
+			out, file = os.Stdout, nil
+		case "stderr":
+			out, file = logger.stderr, nil
+		default:
+			f, err := fsys.OpenFile(config.Output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+			if err != nil {
+				return err
+			}
+			out, file = f, f
+		}
+		if logger.file != nil {
+			logger.file.Close()
+		}
+	}
+	logger.config = config
+	logger.fsys = fsys
+	logger.out = out
+	logger.file = file
+	var w io.Writer = out
+	if config.Format == LogFormatJson {
+		log.SetFlags(0)
+		w = &jsonLineWriter{w: out, level: LogLevelDebug}
+		if err := startJsonStderr(out); err != nil {
+			return err
+		}
+	} else {
+		log.SetFlags(log.LstdFlags)
+	}
+	log.SetOutput(io.MultiWriter(w, DebugLogs))
+	return nil
+}
+
+// Writes any output still buffered for json logging and closes the log file. Must be called
+// before the process exits.
+func CloseLogger() {
+	logger.Lock()
+	defer logger.Unlock()
+	stopJsonStderr()
+	if logger.file != nil {
+		logger.file.Close()
+		logger.file = nil
+	}
+	logger.config = logging{Level: logger.config.Level, Format: LogFormatText, Output: "stderr"}
+	logger.out = logger.stderr
+	log.SetFlags(log.LstdFlags)
+	log.SetOutput(io.MultiWriter(logger.stderr, DebugLogs))
+}
+
+// Replaces os.Stderr with a pipe, so that messages printed directly to stderr are also wrapped
+// in json objects. Caller must hold the logger lock.
+func startJsonStderr(out io.Writer) error {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer r.Close()
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			if line := ansiPattern.ReplaceAllString(scanner.Text(), ""); len(strings.TrimSpace(line)) > 0 {
+				writeJsonLog(out, LogLevelInfo, line)
+			}
+		}
+	}()
+	logger.pipe, logger.done = w, done
+	os.Stderr = w
+	return nil
+}
+
+// Caller must hold the logger lock.
+func stopJsonStderr() {
+	if logger.pipe == nil {
+		return
+	}
+	os.Stderr = logger.stderr
+	logger.pipe.Close()
+	<-logger.done
+	logger.pipe, logger.done = nil, nil
+}
+
+// Returns true if debug output is enabled by the --debug flag or by setting logging.level to debug.
+func DebugEnabled() bool {
+	return logEnabled(LogLevelDebug)
+}
+
+// Returns true if messages at the given level should be written to the configured output.
+func logEnabled(level LogLevel) bool {
+	if viper.GetBool("DEBUG") {
+		return true
+	}
+	logger.Lock()
+	defer logger.Unlock()
+	return logLevels[level] >= logLevels[logger.config.Level]
+}
+
+func writeLog(level LogLevel, msg string) {
+	logger.Lock()
+	defer logger.Unlock()
+	if logger.config.Format == LogFormatJson {
+		writeJsonLog(logger.out, level, ansiPattern.ReplaceAllString(msg, ""))
+		return
+	}
+	prefix := strings.ToUpper(string(level)) + ":"
+	if level == LogLevelWarn {
+		prefix = Yellow("WARNING:")
+	}
+	io.WriteString(logger.out, prefix+" "+msg+"\n")
+}
+
+func writeJsonLog(w io.Writer, level LogLevel, msg string) {
+	line, err := json.Marshal(struct {
+		Time  string   `json:"time"`
+		Level LogLevel `json:"level"`
+		Msg   string   `json:"msg"`
+	}{
+		Time:  time.Now().UTC().Format(time.RFC3339),
+		Level: level,
+		Msg:   msg,
+	})
+	if err != nil {
+		return
+	}
+	w.Write(append(line, '\n'))
+}
+
+// Wraps each line written by the std logger in a json object.
+type jsonLineWriter struct {
+	w     io.Writer
+	level LogLevel
+}
+
+func (j *jsonLineWriter) Write(p []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		writeJsonLog(j.w, j.level, line)
+	}
+	return len(p), nil
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/go-git/go-git/v5"
 	"github.com/spf13/afero"
+	"github.com/spf13/viper"
 )
 
 // Version is assigned using `-ldflags` https://stackoverflow.com/q/11354518.
@@ -169,6 +170,7 @@ var (
 	ProjectRefPath        = filepath.Join(SupabaseDirPath, TempDir, "project-ref")
 	RemoteDbPath          = filepath.Join(SupabaseDirPath, TempDir, "remote-db-url")
 	GotrueVersionPath     = filepath.Join(SupabaseDirPath, TempDir, "gotrue-version")
+	AppliedConfigPath     = filepath.Join(SupabaseDirPath, TempDir, "applied-config.toml")
 	CurrBranchPath        = filepath.Join(SupabaseDirPath, ".branches", "_current_branch")
 	MigrationsDir         = filepath.Join(SupabaseDirPath, "migrations")
 	FunctionsDir          = filepath.Join(SupabaseDirPath, "functions")
@@ -177,12 +179,27 @@ var (
 	DbTestsDir            = filepath.Join(SupabaseDirPath, "tests")
 	SeedDataPath          = filepath.Join(SupabaseDirPath, "seed.sql")
 	CustomRolesPath       = filepath.Join(SupabaseDirPath, "roles.sql")
+	CrashDir              = filepath.Join(SupabaseDirPath, ".supabase", "crash")
+	EnvPath               = ".env"
+	EncryptedEnvPath      = ".env.age"
+	EnvExamplePath        = ".env.example"
 
 	ErrNotLinked  = errors.New("Cannot find project ref. Have you run " + Aqua("supabase link") + "?")
 	ErrInvalidRef = errors.New("Invalid project ref format. Must be like `abcdefghijklmnopqrst`.")
 	ErrNotRunning = errors.New(Aqua("supabase start") + " is not running.")
 )
 
+// Points ConfigPath at a config file in a non-standard layout, such as a monorepo with
+// services/api/supabase/config.toml. Env files are looked up in the directory that contains the
+// supabase directory, the same way .env sits next to supabase/ in the standard layout.
+func SetConfigPath(path string) {
+	ConfigPath = filepath.Clean(path)
+	projectDir := filepath.Dir(filepath.Dir(ConfigPath))
+	EnvPath = filepath.Join(projectDir, ".env")
+	EncryptedEnvPath = filepath.Join(projectDir, ".env.age")
+	EnvExamplePath = filepath.Join(projectDir, ".env.example")
+}
+
 func GetCurrentTimestamp() string {
 	// Magic number: https://stackoverflow.com/q/45160822.
 	return time.Now().UTC().Format("20060102150405")
@@ -244,15 +261,21 @@ func IsGitRepo() bool {
 // If the `os.Getwd()` is within a supabase project, this will return
 // the root of the given project as the current working directory.
 // Otherwise, the `os.Getwd()` is kept as is.
+// Walks up from the current directory to the nearest parent containing supabase/config.toml,
+// stopping at the filesystem root or after SUPABASE_WORKDIR_DEPTH parents when set.
 func GetProjectRoot(fsys afero.Fs) (string, error) {
+	maxDepth := viper.GetInt("WORKDIR_DEPTH")
 	origWd, err := os.Getwd()
-	for cwd := origWd; err == nil; cwd = filepath.Dir(cwd) {
-		path := filepath.Join(cwd, ConfigPath)
+	for cwd, depth := origWd, 0; err == nil; cwd, depth = filepath.Dir(cwd), depth+1 {
+		path := ConfigPath
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(cwd, path)
+		}
 		// Treat all errors as file not exists
 		if isSupaProj, _ := afero.Exists(fsys, path); isSupaProj {
 			return cwd, nil
 		}
-		if isRootDirectory(cwd) {
+		if isRootDirectory(cwd) || (maxDepth > 0 && depth >= maxDepth) {
 			break
 		}
 	}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/spf13/afero"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,6 +25,40 @@ func (m *MockFs) Stat(name string) (fs.FileInfo, error) {
 	return m.MemMapFs.Stat(name)
 }
 
+func TestSetConfigPath(t *testing.T) {
+	original := []string{ConfigPath, EnvPath, EncryptedEnvPath, EnvExamplePath}
+	defer func() {
+		ConfigPath, EnvPath, EncryptedEnvPath, EnvExamplePath = original[0], original[1], original[2], original[3]
+	}()
+
+	t.Run("keeps env files in standard layout", func(t *testing.T) {
+		SetConfigPath("supabase/config.staging.toml")
+		assert.Equal(t, filepath.Join("supabase", "config.staging.toml"), ConfigPath)
+		assert.Equal(t, ".env", EnvPath)
+		assert.Equal(t, ".env.age", EncryptedEnvPath)
+	})
+
+	t.Run("loads env file next to nested supabase directory", func(t *testing.T) {
+		SetConfigPath("services/api/supabase/config.toml")
+		assert.Equal(t, filepath.Join("services", "api", ".env"), EnvPath)
+		assert.Equal(t, filepath.Join("services", "api", ".env.example"), EnvExamplePath)
+		defer os.Unsetenv("GITHUB_SECRET")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, ConfigPath, []byte(`project_id = "test"
+[auth.external.github]
+enabled = true
+client_id = "test-client"
+secret = "env(GITHUB_SECRET)"
+`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join("services", "api", ".env"), []byte("GITHUB_SECRET=nested\n"), 0644))
+		// Run test
+		assert.NoError(t, LoadConfigFS(fsys))
+		// Check config
+		assert.Equal(t, "nested", Config.Auth.External["github"].Secret)
+	})
+}
+
 func TestProjectRoot(t *testing.T) {
 	t.Run("searches project root recursively", func(t *testing.T) {
 		// Setup in-memory fs
@@ -49,6 +84,36 @@ func TestProjectRoot(t *testing.T) {
 		assert.Equal(t, cwd, path)
 	})
 
+	t.Run("searches custom config path", func(t *testing.T) {
+		defer func(path string) { ConfigPath = path }(ConfigPath)
+		ConfigPath = filepath.Join(SupabaseDirPath, "config.test.toml")
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		_, err := fsys.Create(filepath.Join("/", ConfigPath))
+		require.NoError(t, err)
+		// Run test
+		path, err := GetProjectRoot(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "/", path)
+	})
+
+	t.Run("stops at max depth", func(t *testing.T) {
+		viper.Set("WORKDIR_DEPTH", 1)
+		defer viper.Set("WORKDIR_DEPTH", 0)
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		_, err = fsys.Create(filepath.Join("/", ConfigPath))
+		require.NoError(t, err)
+		// Run test
+		path, err := GetProjectRoot(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, cwd, path)
+	})
+
 	t.Run("ignores error if path is not directory", func(t *testing.T) {
 		cwd, err := os.Getwd()
 		require.NoError(t, err)
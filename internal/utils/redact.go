@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+const redacted = "<redacted>"
+
+var (
+	jwtPattern         = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	accessTokenPattern = regexp.MustCompile(`sbp_[a-f0-9]{40}`)
+	urlPasswordPattern = regexp.MustCompile(`(://[^:/@\s]+:)[^@\s]+@`)
+	dsnPasswordPattern = regexp.MustCompile(`(\bpassword=)[^\s]+`)
+)
+
+// Masks known secrets from arbitrary text, such as logs and crash dumps, before
+// they are written to disk or shared with others.
+func RedactSecrets(text string) string {
+	secrets := []string{
+		Config.Auth.JwtSecret,
+		Config.Auth.AnonKey,
+		Config.Auth.ServiceRoleKey,
+		Config.Analytics.ApiKey,
+	}
+	// The default password is public, and masking a common word would garble the text
+	if Config.Db.Password != defaultDbPassword {
+		secrets = append(secrets, Config.Db.Password)
+	}
+	// Provider client secrets, smtp and s3 credentials, resolved from env on load
+	for _, secret := range Config.secretFields() {
+		if !envPattern.MatchString(secret.Value) {
+			secrets = append(secrets, secret.Value)
+		}
+	}
+	for _, secret := range secrets {
+		if len(secret) > 0 {
+			text = strings.ReplaceAll(text, secret, redacted)
+		}
+	}
+	text = jwtPattern.ReplaceAllString(text, redacted)
+	text = accessTokenPattern.ReplaceAllString(text, redacted)
+	text = dsnPasswordPattern.ReplaceAllString(text, "${1}"+redacted)
+	return urlPasswordPattern.ReplaceAllString(text, "${1}"+redacted+"@")
+}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Encodes a string as a TOML basic string. JSON escape sequences are a subset of TOML's.
+func tomlQuote(value string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(value)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// Encodes a table key, quoting it unless it is a valid bare key.
+func tomlKey(key string) string {
+	if bareKeyPattern.MatchString(key) {
+		return key
+	}
+	return tomlQuote(key)
+}
+
+var bareKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Encodes a decoded TOML primitive or array as an inline TOML value.
+func tomlValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return tomlQuote(v)
+	case []interface{}:
+		encoded := make([]string, len(v))
+		for i, item := range v {
+			encoded[i] = tomlValue(item)
+		}
+		return "[" + strings.Join(encoded, ", ") + "]"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// Sets a key under the given table to an already encoded TOML value. Unlike a full rewrite,
+// this preserves comments and unrelated content. Commented out keys are uncommented in place.
+func setTomlValue(content, table, key, value string) string {
+	lines := strings.Split(content, "\n")
+	header := "[" + table + "]"
+	assignment := key + " = " + value
+	keyPattern := regexp.MustCompile(`^` + regexp.QuoteMeta(key) + `\s*=`)
+	commentedPattern := regexp.MustCompile(`^#\s*` + regexp.QuoteMeta(key) + `\s*=`)
+	// Locate the range of lines belonging to table
+	start, end := -1, len(lines)
+	if len(table) == 0 {
+		start = 0
+	}
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if start < 0 {
+			if trimmed == header {
+				start = i + 1
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") {
+			end = i
+			break
+		}
+	}
+	if start < 0 {
+		return strings.TrimRight(content, "\n") + "\n\n" + header + "\n" + assignment + "\n"
+	}
+	for _, pattern := range []*regexp.Regexp{keyPattern, commentedPattern} {
+		for i := start; i < end; i++ {
+			if pattern.MatchString(strings.TrimSpace(lines[i])) {
+				lines[i] = assignment
+				return strings.Join(lines, "\n")
+			}
+		}
+	}
+	lines = append(lines[:start], append([]string{assignment}, lines[start:]...)...)
+	return strings.Join(lines, "\n")
+}
+
+// Removes a key under the given table, leaving comments and other keys untouched.
+func removeTomlValue(content, table, key string) string {
+	lines := strings.Split(content, "\n")
+	header := "[" + table + "]"
+	keyPattern := regexp.MustCompile(`^` + regexp.QuoteMeta(key) + `\s*=`)
+	inTable := len(table) == 0
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			inTable = trimmed == header
+			continue
+		}
+		if inTable && keyPattern.MatchString(trimmed) {
+			return strings.Join(append(lines[:i], lines[i+1:]...), "\n")
+		}
+	}
+	return content
+}
+
+// Returns the 1-based line number where a dotted key is assigned, falling back to the header
+// of its closest enclosing table. Returns 0 if neither appears in content.
+func findTomlLine(content, key string) int {
+	lines := strings.Split(content, "\n")
+	headers := map[string]int{}
+	current := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			current = strings.Trim(trimmed, "[] ")
+			headers[current] = i + 1
+			continue
+		}
+		if name, _, found := strings.Cut(trimmed, "="); found && !strings.HasPrefix(trimmed, "#") {
+			full := strings.TrimSpace(name)
+			if len(current) > 0 {
+				full = current + "." + full
+			}
+			if full == key {
+				return i + 1
+			}
+		}
+	}
+	for table := key; len(table) > 0; table, _ = splitTomlKey(table) {
+		if line, ok := headers[table]; ok {
+			return line
+		}
+	}
+	return 0
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+var collector struct {
+	sync.Mutex
+	warnings *[]string
+}
+
+// Prints a warning to the configured log output unless suppressed by the --quiet flag or by
+// setting logging.level to error.
+func Warnf(format string, a ...any) {
+	msg := fmt.Sprintf(format, a...)
+	collector.Lock()
+	if collector.warnings != nil {
+		*collector.warnings = append(*collector.warnings, msg)
+		collector.Unlock()
+		return
+	}
+	collector.Unlock()
+	if viper.GetBool("QUIET") || !logEnabled(LogLevelWarn) {
+		return
+	}
+	writeLog(LogLevelWarn, msg)
+}
+
+// Runs fn and returns the warnings it raised instead of printing them.
+func CollectWarnings(fn func() error) ([]string, error) {
+	warnings := []string{}
+	collector.Lock()
+	// Nested calls restore the outer collector, which receives any warnings re-raised by the caller
+	outer := collector.warnings
+	collector.warnings = &warnings
+	collector.Unlock()
+	defer func() {
+		collector.Lock()
+		collector.warnings = outer
+		collector.Unlock()
+	}()
+	err := fn()
+	collector.Lock()
+	defer collector.Unlock()
+	return warnings, err
+}
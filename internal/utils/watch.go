@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Editors often save in multiple writes, so changes are only reported after a quiet period.
+const configDebounceDelay = 500 * time.Millisecond
+
+// Calls onChange whenever config.toml is written, until the context is cancelled.
+func WatchConfig(ctx context.Context, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+	// Editors often replace the file on save, so watch its parent directory instead
+	if err := watcher.Add(filepath.Dir(ConfigPath)); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Watching for changes to", Bold(ConfigPath)+"...")
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) == filepath.Clean(ConfigPath) && event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				debounce = time.After(configDebounceDelay)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return err
+		case <-debounce:
+			debounce = nil
+			onChange()
+		}
+	}
+}
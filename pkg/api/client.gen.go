@@ -179,6 +179,9 @@ type ClientInterface interface {
 
 	UpdateConfig(ctx context.Context, ref string, body UpdateConfigJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetStorageConfig request
+	GetStorageConfig(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// RemoveCustomHostnameConfig request
 	RemoveCustomHostnameConfig(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -718,6 +721,18 @@ func (c *Client) UpdateConfig(ctx context.Context, ref string, body UpdateConfig
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetStorageConfig(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStorageConfigRequest(c.Server, ref)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) RemoveCustomHostnameConfig(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewRemoveCustomHostnameConfigRequest(c.Server, ref)
 	if err != nil {
@@ -2376,6 +2391,40 @@ func NewUpdateConfigRequestWithBody(server string, ref string, contentType strin
 	return req, nil
 }
 
+// NewGetStorageConfigRequest generates requests for GetStorageConfig
+func NewGetStorageConfigRequest(server string, ref string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ref", runtime.ParamLocationPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/config/storage", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewRemoveCustomHostnameConfigRequest generates requests for RemoveCustomHostnameConfig
 func NewRemoveCustomHostnameConfigRequest(server string, ref string) (*http.Request, error) {
 	var err error
@@ -4252,6 +4301,9 @@ type ClientWithResponsesInterface interface {
 
 	UpdateConfigWithResponse(ctx context.Context, ref string, body UpdateConfigJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateConfigResponse, error)
 
+	// GetStorageConfigWithResponse request
+	GetStorageConfigWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetStorageConfigResponse, error)
+
 	// RemoveCustomHostnameConfigWithResponse request
 	RemoveCustomHostnameConfigWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*RemoveCustomHostnameConfigResponse, error)
 
@@ -4921,6 +4973,28 @@ func (r UpdateConfigResponse) StatusCode() int {
 	return 0
 }
 
+type GetStorageConfigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StorageConfigResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStorageConfigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStorageConfigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type RemoveCustomHostnameConfigResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -6036,6 +6110,15 @@ func (c *ClientWithResponses) UpdateConfigWithResponse(ctx context.Context, ref
 	return ParseUpdateConfigResponse(rsp)
 }
 
+// GetStorageConfigWithResponse request returning *GetStorageConfigResponse
+func (c *ClientWithResponses) GetStorageConfigWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetStorageConfigResponse, error) {
+	rsp, err := c.GetStorageConfig(ctx, ref, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStorageConfigResponse(rsp)
+}
+
 // RemoveCustomHostnameConfigWithResponse request returning *RemoveCustomHostnameConfigResponse
 func (c *ClientWithResponses) RemoveCustomHostnameConfigWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*RemoveCustomHostnameConfigResponse, error) {
 	rsp, err := c.RemoveCustomHostnameConfig(ctx, ref, reqEditors...)
@@ -7091,6 +7174,32 @@ func ParseUpdateConfigResponse(rsp *http.Response) (*UpdateConfigResponse, error
 	return response, nil
 }
 
+// ParseGetStorageConfigResponse parses an HTTP response from a GetStorageConfigWithResponse call
+func ParseGetStorageConfigResponse(rsp *http.Response) (*GetStorageConfigResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStorageConfigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StorageConfigResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseRemoveCustomHostnameConfigResponse parses an HTTP response from a RemoveCustomHostnameConfigWithResponse call
 func ParseRemoveCustomHostnameConfigResponse(rsp *http.Response) (*RemoveCustomHostnameConfigResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
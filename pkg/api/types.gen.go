@@ -247,14 +247,85 @@ type AttributeValue_Default struct {
 
 // AuthConfigResponse defines model for AuthConfigResponse.
 type AuthConfigResponse struct {
-	RateLimitEmailSent *float32 `json:"rate_limit_email_sent,omitempty"`
-	SmtpAdminEmail     *string  `json:"smtp_admin_email,omitempty"`
-	SmtpHost           *string  `json:"smtp_host,omitempty"`
-	SmtpMaxFrequency   *float32 `json:"smtp_max_frequency,omitempty"`
-	SmtpPass           *string  `json:"smtp_pass,omitempty"`
-	SmtpPort           *string  `json:"smtp_port,omitempty"`
-	SmtpSenderName     *string  `json:"smtp_sender_name,omitempty"`
-	SmtpUser           *string  `json:"smtp_user,omitempty"`
+	DisableSignup                *bool    `json:"disable_signup,omitempty"`
+	ExternalAppleClientId        *string  `json:"external_apple_client_id,omitempty"`
+	ExternalAppleEnabled         *bool    `json:"external_apple_enabled,omitempty"`
+	ExternalAppleSecret          *string  `json:"external_apple_secret,omitempty"`
+	ExternalAzureClientId        *string  `json:"external_azure_client_id,omitempty"`
+	ExternalAzureEnabled         *bool    `json:"external_azure_enabled,omitempty"`
+	ExternalAzureSecret          *string  `json:"external_azure_secret,omitempty"`
+	ExternalAzureUrl             *string  `json:"external_azure_url,omitempty"`
+	ExternalBitbucketClientId    *string  `json:"external_bitbucket_client_id,omitempty"`
+	ExternalBitbucketEnabled     *bool    `json:"external_bitbucket_enabled,omitempty"`
+	ExternalBitbucketSecret      *string  `json:"external_bitbucket_secret,omitempty"`
+	ExternalDiscordClientId      *string  `json:"external_discord_client_id,omitempty"`
+	ExternalDiscordEnabled       *bool    `json:"external_discord_enabled,omitempty"`
+	ExternalDiscordSecret        *string  `json:"external_discord_secret,omitempty"`
+	ExternalFacebookClientId     *string  `json:"external_facebook_client_id,omitempty"`
+	ExternalFacebookEnabled      *bool    `json:"external_facebook_enabled,omitempty"`
+	ExternalFacebookSecret       *string  `json:"external_facebook_secret,omitempty"`
+	ExternalFigmaClientId        *string  `json:"external_figma_client_id,omitempty"`
+	ExternalFigmaEnabled         *bool    `json:"external_figma_enabled,omitempty"`
+	ExternalFigmaSecret          *string  `json:"external_figma_secret,omitempty"`
+	ExternalFlyClientId          *string  `json:"external_fly_client_id,omitempty"`
+	ExternalFlyEnabled           *bool    `json:"external_fly_enabled,omitempty"`
+	ExternalFlySecret            *string  `json:"external_fly_secret,omitempty"`
+	ExternalGithubClientId       *string  `json:"external_github_client_id,omitempty"`
+	ExternalGithubEnabled        *bool    `json:"external_github_enabled,omitempty"`
+	ExternalGithubSecret         *string  `json:"external_github_secret,omitempty"`
+	ExternalGitlabClientId       *string  `json:"external_gitlab_client_id,omitempty"`
+	ExternalGitlabEnabled        *bool    `json:"external_gitlab_enabled,omitempty"`
+	ExternalGitlabSecret         *string  `json:"external_gitlab_secret,omitempty"`
+	ExternalGitlabUrl            *string  `json:"external_gitlab_url,omitempty"`
+	ExternalGoogleClientId       *string  `json:"external_google_client_id,omitempty"`
+	ExternalGoogleEnabled        *bool    `json:"external_google_enabled,omitempty"`
+	ExternalGoogleSecret         *string  `json:"external_google_secret,omitempty"`
+	ExternalKakaoClientId        *string  `json:"external_kakao_client_id,omitempty"`
+	ExternalKakaoEnabled         *bool    `json:"external_kakao_enabled,omitempty"`
+	ExternalKakaoSecret          *string  `json:"external_kakao_secret,omitempty"`
+	ExternalKeycloakClientId     *string  `json:"external_keycloak_client_id,omitempty"`
+	ExternalKeycloakEnabled      *bool    `json:"external_keycloak_enabled,omitempty"`
+	ExternalKeycloakSecret       *string  `json:"external_keycloak_secret,omitempty"`
+	ExternalKeycloakUrl          *string  `json:"external_keycloak_url,omitempty"`
+	ExternalLinkedinClientId     *string  `json:"external_linkedin_client_id,omitempty"`
+	ExternalLinkedinEnabled      *bool    `json:"external_linkedin_enabled,omitempty"`
+	ExternalLinkedinOidcClientId *string  `json:"external_linkedin_oidc_client_id,omitempty"`
+	ExternalLinkedinOidcEnabled  *bool    `json:"external_linkedin_oidc_enabled,omitempty"`
+	ExternalLinkedinOidcSecret   *string  `json:"external_linkedin_oidc_secret,omitempty"`
+	ExternalLinkedinSecret       *string  `json:"external_linkedin_secret,omitempty"`
+	ExternalNotionClientId       *string  `json:"external_notion_client_id,omitempty"`
+	ExternalNotionEnabled        *bool    `json:"external_notion_enabled,omitempty"`
+	ExternalNotionSecret         *string  `json:"external_notion_secret,omitempty"`
+	ExternalSlackClientId        *string  `json:"external_slack_client_id,omitempty"`
+	ExternalSlackEnabled         *bool    `json:"external_slack_enabled,omitempty"`
+	ExternalSlackSecret          *string  `json:"external_slack_secret,omitempty"`
+	ExternalSpotifyClientId      *string  `json:"external_spotify_client_id,omitempty"`
+	ExternalSpotifyEnabled       *bool    `json:"external_spotify_enabled,omitempty"`
+	ExternalSpotifySecret        *string  `json:"external_spotify_secret,omitempty"`
+	ExternalTwitchClientId       *string  `json:"external_twitch_client_id,omitempty"`
+	ExternalTwitchEnabled        *bool    `json:"external_twitch_enabled,omitempty"`
+	ExternalTwitchSecret         *string  `json:"external_twitch_secret,omitempty"`
+	ExternalTwitterClientId      *string  `json:"external_twitter_client_id,omitempty"`
+	ExternalTwitterEnabled       *bool    `json:"external_twitter_enabled,omitempty"`
+	ExternalTwitterSecret        *string  `json:"external_twitter_secret,omitempty"`
+	ExternalWorkosClientId       *string  `json:"external_workos_client_id,omitempty"`
+	ExternalWorkosEnabled        *bool    `json:"external_workos_enabled,omitempty"`
+	ExternalWorkosSecret         *string  `json:"external_workos_secret,omitempty"`
+	ExternalWorkosUrl            *string  `json:"external_workos_url,omitempty"`
+	ExternalZoomClientId         *string  `json:"external_zoom_client_id,omitempty"`
+	ExternalZoomEnabled          *bool    `json:"external_zoom_enabled,omitempty"`
+	ExternalZoomSecret           *string  `json:"external_zoom_secret,omitempty"`
+	JwtExp                       *int     `json:"jwt_exp,omitempty"`
+	RateLimitEmailSent           *float32 `json:"rate_limit_email_sent,omitempty"`
+	SiteUrl                      *string  `json:"site_url,omitempty"`
+	SmtpAdminEmail               *string  `json:"smtp_admin_email,omitempty"`
+	SmtpHost                     *string  `json:"smtp_host,omitempty"`
+	SmtpMaxFrequency             *float32 `json:"smtp_max_frequency,omitempty"`
+	SmtpPass                     *string  `json:"smtp_pass,omitempty"`
+	SmtpPort                     *string  `json:"smtp_port,omitempty"`
+	SmtpSenderName               *string  `json:"smtp_sender_name,omitempty"`
+	SmtpUser                     *string  `json:"smtp_user,omitempty"`
+	UriAllowList                 *string  `json:"uri_allow_list,omitempty"`
 }
 
 // BranchDetailResponse defines model for BranchDetailResponse.
@@ -707,6 +778,22 @@ type SslEnforcements struct {
 	Database bool `json:"database"`
 }
 
+// StorageConfigResponse defines model for StorageConfigResponse.
+type StorageConfigResponse struct {
+	Features      StorageFeatures `json:"features"`
+	FileSizeLimit int64           `json:"fileSizeLimit"`
+}
+
+// StorageFeatureImageTransformation defines model for StorageFeatureImageTransformation.
+type StorageFeatureImageTransformation struct {
+	Enabled bool `json:"enabled"`
+}
+
+// StorageFeatures defines model for StorageFeatures.
+type StorageFeatures struct {
+	ImageTransformation StorageFeatureImageTransformation `json:"imageTransformation"`
+}
+
 // SubdomainAvailabilityResponse defines model for SubdomainAvailabilityResponse.
 type SubdomainAvailabilityResponse struct {
 	Available bool `json:"available"`
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Writes the JSON schema of config.toml to the path given as the first argument.
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal("Usage: configschema <output path>")
+	}
+	schema, err := utils.ConfigSchema()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(os.Args[1], schema, 0644); err != nil {
+		log.Fatal(err)
+	}
+}